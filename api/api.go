@@ -1,19 +1,37 @@
 package api
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"api-groupie-tracker/models"
+	"api-groupie-tracker/utils"
+	"strings"
 	"sync"
+	"time"
 )
 
+// DefaultBaseURL est l'URL de base de l'API Groupie Trackers
+const DefaultBaseURL = "https://groupietrackers.herokuapp.com/api"
+
+// Chemins des ressources, relatifs à l'URL de base
 const (
-	ArtistsURL   = "https://groupietrackers.herokuapp.com/api/artists"
-	LocationsURL = "https://groupietrackers.herokuapp.com/api/locations"
-	DatesURL     = "https://groupietrackers.herokuapp.com/api/dates"
-	RelationsURL = "https://groupietrackers.herokuapp.com/api/relation"
+	artistsPath   = "/artists"
+	locationsPath = "/locations"
+	datesPath     = "/dates"
+	relationsPath = "/relation"
+)
+
+// Paramètres d'accès à l'API, fixés au démarrage par Configure
+var (
+	baseURL      = DefaultBaseURL
+	client       = &http.Client{Timeout: 15 * time.Second}
+	snapshotPath string
 )
 
 var (
@@ -22,10 +40,39 @@ var (
 	Dates     models.DateIndex
 	Relations models.RelationIndex
 	mutex     sync.RWMutex
+
+	lastRefresh time.Time
+	dataSource  string
+	dataVersion string
+	feed        []models.FeedEntry
+
+	// Date de la sauvegarde sur disque la plus récente, écrite ou relue
+	snapshotSavedAt time.Time
 )
 
-// FetchAllData récupère toutes les données de l'API en parallèle
-func FetchAllData() error {
+// Nombre maximal d'entrées conservées pour les flux Atom
+const maxFeedEntries = 500
+
+// Configure fixe l'URL de base de l'API, le délai maximal d'une requête et
+// le fichier de sauvegarde ("" pour désactiver). À appeler avant le premier
+// chargement.
+func Configure(upstreamBaseURL string, timeout time.Duration, snapshot string) {
+	baseURL = strings.TrimSuffix(upstreamBaseURL, "/")
+	client = &http.Client{Timeout: timeout}
+	snapshotPath = snapshot
+}
+
+// FetchAllData récupère toutes les données de l'API en parallèle puis
+// remplace le jeu de données courant d'un seul bloc. L'annulation de ctx
+// interrompt les requêtes en cours.
+func FetchAllData(ctx context.Context) error {
+	var (
+		artists   []models.Artist
+		locations models.LocationIndex
+		dates     models.DateIndex
+		relations models.RelationIndex
+	)
+
 	var wg sync.WaitGroup
 	errors := make(chan error, 4)
 
@@ -34,7 +81,7 @@ func FetchAllData() error {
 	// Récupérer les artistes
 	go func() {
 		defer wg.Done()
-		if err := fetchArtists(); err != nil {
+		if err := fetchJSON(ctx, artistsPath, &artists); err != nil {
 			errors <- fmt.Errorf("erreur artistes: %w", err)
 		}
 	}()
@@ -42,7 +89,7 @@ func FetchAllData() error {
 	// Récupérer les locations
 	go func() {
 		defer wg.Done()
-		if err := fetchLocations(); err != nil {
+		if err := fetchJSON(ctx, locationsPath, &locations); err != nil {
 			errors <- fmt.Errorf("erreur locations: %w", err)
 		}
 	}()
@@ -50,7 +97,7 @@ func FetchAllData() error {
 	// Récupérer les dates
 	go func() {
 		defer wg.Done()
-		if err := fetchDates(); err != nil {
+		if err := fetchJSON(ctx, datesPath, &dates); err != nil {
 			errors <- fmt.Errorf("erreur dates: %w", err)
 		}
 	}()
@@ -58,7 +105,7 @@ func FetchAllData() error {
 	// Récupérer les relations
 	go func() {
 		defer wg.Done()
-		if err := fetchRelations(); err != nil {
+		if err := fetchJSON(ctx, relationsPath, &relations); err != nil {
 			errors <- fmt.Errorf("erreur relations: %w", err)
 		}
 	}()
@@ -72,80 +119,90 @@ func FetchAllData() error {
 		}
 	}
 
-	return nil
-}
+	replaceData(artists, locations, dates, relations, time.Now().UTC(), SourceAPI)
 
-func fetchArtists() error {
-	resp, err := http.Get(ArtistsURL)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("status code: %d", resp.StatusCode)
+	if snapshotPath != "" {
+		if err := SaveSnapshot(snapshotPath); err != nil {
+			log.Println("Erreur lors de la sauvegarde des données:", err)
+		}
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
+// replaceData remplace le jeu de données courant d'un seul bloc, reconstruit
+// les données dérivées et publie les nouveautés. source indique l'origine
+// des données (SourceAPI ou SourceSnapshot).
+func replaceData(artists []models.Artist, locations models.LocationIndex, dates models.DateIndex, relations models.RelationIndex, at time.Time, source string) {
 	mutex.Lock()
-	err = json.Unmarshal(body, &Artists)
-	mutex.Unlock()
+	defer mutex.Unlock()
 
-	return err
-}
+	previousArtists, previousConcerts := Artists, Concerts
+	firstLoad := lastRefresh.IsZero()
 
-func fetchLocations() error {
-	resp, err := http.Get(LocationsURL)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+	Artists, Locations, Dates, Relations = artists, locations, dates, relations
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("status code: %d", resp.StatusCode)
-	}
+	// Construire les données dérivées (concerts, index spatial, analyses)
+	buildDerived()
+	lastRefresh = at
+	dataSource = source
+	dataVersion = datasetVersion(artists, locations, dates, relations)
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	// Publier les nouveautés par rapport au chargement précédent
+	if !firstLoad {
+		entries := utils.DiffDatasets(previousArtists, Artists, previousConcerts, Concerts, lastRefresh)
+		feed = append(entries, feed...)
+		if len(feed) > maxFeedEntries {
+			feed = feed[:maxFeedEntries]
+		}
 	}
-
-	mutex.Lock()
-	err = json.Unmarshal(body, &Locations)
-	mutex.Unlock()
-
-	return err
 }
 
-func fetchDates() error {
-	resp, err := http.Get(DatesURL)
-	if err != nil {
-		return err
+// datasetVersion résume le contenu des données : deux chargements
+// identiques donnent la même version
+func datasetVersion(artists []models.Artist, locations models.LocationIndex, dates models.DateIndex, relations models.RelationIndex) string {
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+	for _, v := range []interface{}{artists, locations, dates, relations} {
+		if err := enc.Encode(v); err != nil {
+			return ""
+		}
 	}
-	defer resp.Body.Close()
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("status code: %d", resp.StatusCode)
+// RunRefresher recharge les données toutes les interval jusqu'à l'annulation
+// du contexte. Un échec conserve les données précédentes.
+func RunRefresher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := FetchAllData(ctx); err != nil && ctx.Err() == nil {
+				log.Println("Erreur lors du rafraîchissement des données:", err)
+			}
+		}
 	}
+}
 
-	body, err := io.ReadAll(resp.Body)
+// fetchJSON décode la ressource path de l'API dans v
+func fetchJSON(ctx context.Context, path string, v interface{}) (err error) {
+	start := time.Now()
+	defer func() {
+		observeFetch(path, start, err)
+		recordFetch(path, start, err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
 	if err != nil {
 		return err
 	}
 
-	mutex.Lock()
-	err = json.Unmarshal(body, &Dates)
-	mutex.Unlock()
-
-	return err
-}
-
-func fetchRelations() error {
-	resp, err := http.Get(RelationsURL)
+	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -160,11 +217,7 @@ func fetchRelations() error {
 		return err
 	}
 
-	mutex.Lock()
-	err = json.Unmarshal(body, &Relations)
-	mutex.Unlock()
-
-	return err
+	return json.Unmarshal(body, v)
 }
 
 // GetArtistByID retourne un artiste par son ID
@@ -212,6 +265,28 @@ func GetFullArtistByID(id int) (*models.FullArtist, error) {
 	return fullArtist, nil
 }
 
+// GetLastRefresh retourne la date du dernier chargement réussi des données
+func GetLastRefresh() time.Time {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return lastRefresh
+}
+
+// GetDataVersion retourne la version du jeu de données courant, qui change
+// avec son contenu ("" avant le premier chargement)
+func GetDataVersion() string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return dataVersion
+}
+
+// GetFeedEntries retourne les nouveautés détectées depuis le démarrage
+func GetFeedEntries() []models.FeedEntry {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return feed
+}
+
 // GetAllArtists retourne tous les artistes
 func GetAllArtists() []models.Artist {
 	mutex.RLock()
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"sort"
+
+	"api-groupie-tracker/geo"
+	"api-groupie-tracker/models"
+	"api-groupie-tracker/utils"
+)
+
+const (
+	// Taille des cellules de l'index spatial, en degrés
+	concertCellSize = 2.0
+
+	// OverlapWindowDays est l'écart maximal (en jours) entre deux concerts
+	// dans la même location pour les considérer comme un co-plateau
+	OverlapWindowDays = 1
+
+	// SimilarLimit est le nombre d'artistes recommandés par artiste
+	SimilarLimit = 5
+)
+
+var (
+	Concerts     []models.Concert
+	concertIndex = geo.NewIndex(concertCellSize)
+	positions    map[string]geo.Point
+	approximate  map[string]bool
+	overlaps     map[int][]models.Overlap
+	similar      map[int][]models.SimilarArtist
+	stats        models.Stats
+)
+
+// buildDerived reconstruit la liste des concerts, l'index spatial, les
+// co-plateaux, les recommandations et les statistiques à partir des
+// artistes et relations chargés. L'appelant détient le verrou.
+func buildDerived() {
+	Concerts = utils.BuildConcerts(Artists, Relations.Index)
+	concertIndex = geo.NewIndex(concertCellSize)
+	positions = make(map[string]geo.Point)
+	approximate = make(map[string]bool)
+
+	for i, concert := range Concerts {
+		p, found := positions[concert.Location]
+		if !found {
+			var exact, ok bool
+			p, exact, ok = geo.Lookup(concert.Location)
+			if !ok {
+				continue
+			}
+			positions[concert.Location] = p
+			approximate[concert.Location] = !exact
+		}
+		concertIndex.Insert(p, i)
+	}
+
+	overlaps = utils.FindOverlaps(Concerts, OverlapWindowDays)
+	similar = utils.RankSimilar(Artists, Concerts, overlaps, SimilarLimit)
+	stats = utils.ComputeStats(Artists, Concerts)
+}
+
+// GetNearbyConcerts retourne les concerts situés dans le rayon demandé,
+// triés par distance puis par date
+func GetNearbyConcerts(criteria models.NearbyCriteria) []models.NearbyConcert {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	center := geo.Point{Lat: criteria.Lat, Lon: criteria.Lon}
+	results := []models.NearbyConcert{}
+
+	for _, match := range concertIndex.Within(center, criteria.RadiusKm) {
+		concert := Concerts[match.ID]
+		if !criteria.From.IsZero() && concert.Date.Before(criteria.From) {
+			continue
+		}
+		if !criteria.To.IsZero() && concert.Date.After(criteria.To) {
+			continue
+		}
+
+		p := positions[concert.Location]
+		results = append(results, models.NearbyConcert{
+			Concert:     concert,
+			Lat:         p.Lat,
+			Lon:         p.Lon,
+			DistanceKm:  match.DistanceKm,
+			Approximate: approximate[concert.Location],
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].DistanceKm != results[j].DistanceKm {
+			return results[i].DistanceKm < results[j].DistanceKm
+		}
+		return results[i].Date.Before(results[j].Date)
+	})
+
+	return results
+}
+
+// GetLocationPosition géocode une location connue du répertoire
+func GetLocationPosition(location string) (geo.Point, bool) {
+	p, _, ok := geo.Lookup(location)
+	return p, ok
+}
+
+// GetArtistPlaces retourne les lieux de concert d'un artiste avec leur
+// position, dans l'ordre de son premier concert, chaque lieu avec ses dates
+func GetArtistPlaces(id int) []models.MapPlace {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	places := []models.MapPlace{}
+	byLocation := make(map[string]int)
+	for _, concert := range Concerts {
+		if concert.ArtistID != id {
+			continue
+		}
+		i, found := byLocation[concert.Location]
+		if !found {
+			p, located := positions[concert.Location]
+			places = append(places, models.MapPlace{
+				Location:    concert.Location,
+				Name:        utils.FormatLocation(concert.Location),
+				Lat:         p.Lat,
+				Lon:         p.Lon,
+				Located:     located,
+				Approximate: approximate[concert.Location],
+			})
+			i = len(places) - 1
+			byLocation[concert.Location] = i
+		}
+		places[i].Dates = append(places[i].Dates, models.LocalDate{Time: concert.Date})
+	}
+	return places
+}
+
+// GetOverlaps retourne les concerts d'autres artistes partageant une location
+// et une date (à un jour près) avec l'artiste
+func GetOverlaps(id int) []models.Overlap {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	list := overlaps[id]
+	if list == nil {
+		return []models.Overlap{}
+	}
+	return list
+}
+
+// GetSimilar retourne les artistes recommandés pour un artiste
+func GetSimilar(id int) []models.SimilarArtist {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	list := similar[id]
+	if list == nil {
+		return []models.SimilarArtist{}
+	}
+	return list
+}
+
+// GetStats retourne les statistiques calculées au chargement
+func GetStats() models.Stats {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return stats
+}
+
+// GetConcerts retourne tous les concerts triés par date
+func GetConcerts() []models.Concert {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return Concerts
+}
+
+// GetLocationConcerts retourne les concerts d'une location, triés par date
+func GetLocationConcerts(location string) []models.Concert {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	key := utils.NormalizeLocation(location)
+	var concerts []models.Concert
+	for _, concert := range Concerts {
+		if utils.NormalizeLocation(concert.Location) == key {
+			concerts = append(concerts, concert)
+		}
+	}
+	return concerts
+}
+
+// GetLocationNames retourne les locations ayant au moins un concert, triées
+func GetLocationNames() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, concert := range Concerts {
+		key := utils.NormalizeLocation(concert.Location)
+		if !seen[key] {
+			seen[key] = true
+			names = append(names, concert.Location)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"api-groupie-tracker/models"
+)
+
+// Origines possibles du jeu de données courant
+const (
+	SourceAPI      = "api"
+	SourceSnapshot = "snapshot"
+)
+
+// État des appels à l'API, par ressource. Les requêtes étant parallèles et
+// hors du verrou des données, il a son propre verrou.
+var (
+	upstream      = make(map[string]*models.UpstreamStatus)
+	upstreamMutex sync.Mutex
+)
+
+// recordFetch met à jour l'état de la ressource path après un appel
+func recordFetch(path string, at time.Time, err error) {
+	endpoint := strings.TrimPrefix(path, "/")
+
+	upstreamMutex.Lock()
+	defer upstreamMutex.Unlock()
+
+	status, ok := upstream[endpoint]
+	if !ok {
+		status = &models.UpstreamStatus{Endpoint: endpoint}
+		upstream[endpoint] = status
+	}
+	status.LastAttempt = &at
+	status.OK = err == nil
+	if err != nil {
+		status.LastError = err.Error()
+	} else {
+		status.LastSuccess = &at
+		status.LastError = ""
+	}
+}
+
+// GetDataState retourne l'origine et la taille du jeu de données courant,
+// l'état de chaque ressource de l'API et celui de la sauvegarde
+func GetDataState() models.DataState {
+	mutex.RLock()
+	state := models.DataState{
+		Source:          dataSource,
+		LastRefresh:     lastRefresh,
+		Artists:         len(Artists),
+		Concerts:        len(Concerts),
+		SnapshotPath:    snapshotPath,
+		SnapshotSavedAt: snapshotSavedAt,
+	}
+	mutex.RUnlock()
+
+	upstreamMutex.Lock()
+	defer upstreamMutex.Unlock()
+
+	// Toutes les ressources figurent, même avant le premier appel
+	for _, path := range []string{artistsPath, locationsPath, datesPath, relationsPath} {
+		endpoint := strings.TrimPrefix(path, "/")
+		status := models.UpstreamStatus{Endpoint: endpoint}
+		if s, ok := upstream[endpoint]; ok {
+			status = *s
+		}
+		state.Upstream = append(state.Upstream, status)
+	}
+	return state
+}
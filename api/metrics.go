@@ -0,0 +1,50 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"api-groupie-tracker/metrics"
+)
+
+// Métriques des appels à l'API, par ressource (artists, locations...)
+var (
+	upstreamDuration = metrics.NewHistogram("groupie_upstream_fetch_duration_seconds",
+		"Durée des requêtes vers l'API Groupie Trackers.", metrics.DefaultBuckets, "endpoint")
+	upstreamFailures = metrics.NewCounter("groupie_upstream_fetch_failures_total",
+		"Requêtes vers l'API Groupie Trackers en échec.", "endpoint")
+)
+
+// Métriques du jeu de données courant, lues au moment de l'export
+func init() {
+	metrics.NewGaugeFunc("groupie_dataset_artists", "Nombre d'artistes chargés.", func() float64 {
+		mutex.RLock()
+		defer mutex.RUnlock()
+		return float64(len(Artists))
+	})
+	metrics.NewGaugeFunc("groupie_dataset_concerts", "Nombre de concerts chargés.", func() float64 {
+		mutex.RLock()
+		defer mutex.RUnlock()
+		return float64(len(Concerts))
+	})
+	metrics.NewGaugeFunc("groupie_dataset_locations", "Nombre de locations distinctes.", func() float64 {
+		return float64(len(GetLocationNames()))
+	})
+	metrics.NewGaugeFunc("groupie_last_refresh_timestamp_seconds",
+		"Date (timestamp Unix) du dernier chargement réussi, 0 avant le premier.", func() float64 {
+			at := GetLastRefresh()
+			if at.IsZero() {
+				return 0
+			}
+			return float64(at.UnixNano()) / float64(time.Second)
+		})
+}
+
+// observeFetch enregistre la durée et l'éventuel échec d'une requête
+func observeFetch(path string, start time.Time, err error) {
+	endpoint := strings.TrimPrefix(path, "/")
+	upstreamDuration.Observe(time.Since(start).Seconds(), endpoint)
+	if err != nil {
+		upstreamFailures.Inc(endpoint)
+	}
+}
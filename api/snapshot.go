@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"api-groupie-tracker/models"
+)
+
+// snapshot est la sauvegarde sur disque des quatre ressources de l'API,
+// relue au démarrage si l'API est indisponible
+type snapshot struct {
+	SavedAt   time.Time            `json:"savedAt"`
+	Artists   []models.Artist      `json:"artists"`
+	Locations models.LocationIndex `json:"locations"`
+	Dates     models.DateIndex     `json:"dates"`
+	Relations models.RelationIndex `json:"relations"`
+}
+
+// SaveSnapshot écrit le jeu de données courant dans path. Le fichier est
+// remplacé atomiquement : une sauvegarde interrompue ne corrompt pas la
+// précédente.
+func SaveSnapshot(path string) error {
+	mutex.RLock()
+	savedAt := lastRefresh
+	data, err := json.Marshal(snapshot{
+		SavedAt:   savedAt,
+		Artists:   Artists,
+		Locations: Locations,
+		Dates:     Dates,
+		Relations: Relations,
+	})
+	mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	mutex.Lock()
+	snapshotSavedAt = savedAt
+	mutex.Unlock()
+	return nil
+}
+
+// LoadSnapshot remplace le jeu de données courant par la sauvegarde path.
+// La date de dernier chargement devient celle de la sauvegarde.
+func LoadSnapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("sauvegarde %s illisible: %w", path, err)
+	}
+	if len(snap.Artists) == 0 || snap.SavedAt.IsZero() {
+		return fmt.Errorf("sauvegarde %s vide", path)
+	}
+
+	replaceData(snap.Artists, snap.Locations, snap.Dates, snap.Relations, snap.SavedAt, SourceSnapshot)
+
+	mutex.Lock()
+	snapshotSavedAt = snap.SavedAt
+	mutex.Unlock()
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+
+	"api-groupie-tracker/config"
+)
+
+// assets embarque les templates et les fichiers statiques dans le binaire,
+// qui fonctionne ainsi depuis n'importe quel répertoire
+//
+//go:embed templates/*.html static
+var assets embed.FS
+
+// assetDirs retourne les templates et les fichiers statiques : les copies
+// embarquées, ou les répertoires du disque en mode développement
+func assetDirs(cfg config.Config) (templates, static fs.FS, err error) {
+	if cfg.Dev {
+		return os.DirFS(cfg.TemplateDir), os.DirFS(cfg.StaticDir), nil
+	}
+
+	if templates, err = fs.Sub(assets, "templates"); err != nil {
+		return nil, nil, err
+	}
+	if static, err = fs.Sub(assets, "static"); err != nil {
+		return nil, nil, err
+	}
+	return templates, static, nil
+}
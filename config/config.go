@@ -0,0 +1,368 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config regroupe les paramètres du serveur. Ordre de priorité :
+// valeurs par défaut < fichier JSON < variables d'environnement < flags
+type Config struct {
+	Addr            string
+	UpstreamBaseURL string
+	RefreshInterval time.Duration
+	SnapshotPath    string
+
+	// MaxDataAge est l'âge maximal des données au-delà duquel /readyz
+	// signale le serveur comme indisponible (0 pour ne pas vérifier)
+	MaxDataAge time.Duration
+
+	TemplateDir string
+	StaticDir   string
+
+	// TrustedProxyHeader est l'en-tête (X-Forwarded-For, X-Real-IP...)
+	// donnant l'IP du client derrière un proxy. Vide : adresse de connexion.
+	TrustedProxyHeader string
+
+	// PublicURL est l'origine publique du site (https://groupie.example),
+	// utilisée pour les liens absolus : canonical, flux, sitemap. Vide :
+	// déduite de l'en-tête Host de la requête.
+	PublicURL string
+
+	// GoogleMapsKey active la carte interactive Google Maps de la page
+	// artiste. Vide : seule la carte SVG rendue par le serveur est affichée.
+	GoogleMapsKey string
+
+	// Dev relit templates et fichiers statiques depuis TemplateDir et
+	// StaticDir à chaque requête, au lieu des copies embarquées
+	Dev bool
+
+	UpstreamTimeout time.Duration
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// Default retourne la configuration historique du projet
+func Default() Config {
+	return Config{
+		Addr:            ":8080",
+		UpstreamBaseURL: "https://groupietrackers.herokuapp.com/api",
+		RefreshInterval: time.Hour,
+		MaxDataAge:      3 * time.Hour,
+		TemplateDir:     "templates",
+		StaticDir:       "static",
+
+		UpstreamTimeout: 15 * time.Second,
+		ReadTimeout:     10 * time.Second,
+		WriteTimeout:    30 * time.Second,
+		IdleTimeout:     2 * time.Minute,
+		ShutdownTimeout: 15 * time.Second,
+	}
+}
+
+// Préfixe des variables d'environnement (GROUPIE_ADDR, GROUPIE_CONFIG...)
+const envPrefix = "GROUPIE_"
+
+// Intervalle minimal entre deux rafraîchissements, pour ménager l'API
+const minRefreshInterval = 10 * time.Second
+
+/*
+	setting
+	➡️ un paramètre, avec le même nom dans le fichier (addr),
+	   l'environnement (GROUPIE_ADDR) et les flags (-addr)
+*/
+type setting struct {
+	name    string
+	usage   string
+	boolean bool
+	set     func(c *Config, value string) error
+	get     func(c *Config) string
+}
+
+func (s setting) env() string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(s.name, "-", "_"))
+}
+
+func stringSetting(name, usage string, field func(c *Config) *string) setting {
+	return setting{
+		name:  name,
+		usage: usage,
+		set: func(c *Config, value string) error {
+			*field(c) = strings.TrimSpace(value)
+			return nil
+		},
+		get: func(c *Config) string { return *field(c) },
+	}
+}
+
+func durationSetting(name, usage string, field func(c *Config) *time.Duration) setting {
+	return setting{
+		name:  name,
+		usage: usage,
+		set: func(c *Config, value string) error {
+			d, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("durée invalide %q (exemples : 30s, 5m, 1h)", value)
+			}
+			*field(c) = d
+			return nil
+		},
+		get: func(c *Config) string { return field(c).String() },
+	}
+}
+
+func boolSetting(name, usage string, field func(c *Config) *bool) setting {
+	return setting{
+		name:    name,
+		usage:   usage,
+		boolean: true,
+		set: func(c *Config, value string) error {
+			b, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("booléen invalide %q (true ou false)", value)
+			}
+			*field(c) = b
+			return nil
+		},
+		get: func(c *Config) string { return strconv.FormatBool(*field(c)) },
+	}
+}
+
+var settings = []setting{
+	stringSetting("addr", "adresse d'écoute (hôte:port)",
+		func(c *Config) *string { return &c.Addr }),
+	stringSetting("upstream-url", "URL de base de l'API Groupie Trackers",
+		func(c *Config) *string { return &c.UpstreamBaseURL }),
+	durationSetting("refresh-interval", "intervalle de rafraîchissement des données (0 pour désactiver)",
+		func(c *Config) *time.Duration { return &c.RefreshInterval }),
+	stringSetting("snapshot", "fichier de sauvegarde des données, relu si l'API est indisponible au démarrage",
+		func(c *Config) *string { return &c.SnapshotPath }),
+	durationSetting("max-data-age", "âge maximal des données pour /readyz (0 pour ne pas vérifier)",
+		func(c *Config) *time.Duration { return &c.MaxDataAge }),
+	boolSetting("dev", "mode développement : templates et fichiers statiques relus depuis le disque",
+		func(c *Config) *bool { return &c.Dev }),
+	stringSetting("templates", "répertoire des templates HTML (mode développement)",
+		func(c *Config) *string { return &c.TemplateDir }),
+	stringSetting("static", "répertoire des fichiers statiques (mode développement)",
+		func(c *Config) *string { return &c.StaticDir }),
+	stringSetting("trusted-proxy-header", "en-tête donnant l'IP du client derrière un proxy de confiance (ex. X-Forwarded-For)",
+		func(c *Config) *string { return &c.TrustedProxyHeader }),
+	stringSetting("public-url", "URL publique du site pour les liens absolus (vide : déduite de la requête)",
+		func(c *Config) *string { return &c.PublicURL }),
+	stringSetting("google-maps-key", "clé API Google Maps pour la carte interactive (vide : carte statique seule)",
+		func(c *Config) *string { return &c.GoogleMapsKey }),
+	durationSetting("upstream-timeout", "délai maximal d'une requête vers l'API",
+		func(c *Config) *time.Duration { return &c.UpstreamTimeout }),
+	durationSetting("read-timeout", "délai maximal de lecture d'une requête",
+		func(c *Config) *time.Duration { return &c.ReadTimeout }),
+	durationSetting("write-timeout", "délai maximal d'écriture d'une réponse",
+		func(c *Config) *time.Duration { return &c.WriteTimeout }),
+	durationSetting("idle-timeout", "durée de vie d'une connexion keep-alive inactive",
+		func(c *Config) *time.Duration { return &c.IdleTimeout }),
+	durationSetting("shutdown-timeout", "délai laissé aux requêtes en cours à l'arrêt du serveur",
+		func(c *Config) *time.Duration { return &c.ShutdownTimeout }),
+}
+
+func lookup(name string) (setting, bool) {
+	for _, s := range settings {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// Load construit la configuration à partir des arguments de la ligne de
+// commande (sans le nom du programme) et de l'environnement. Le fichier est
+// désigné par -config ou GROUPIE_CONFIG. Retourne flag.ErrHelp pour -h.
+func Load(args []string, getenv func(string) string, output io.Writer) (Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("groupie-tracker", flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	configPath := fs.String("config", "", "fichier de configuration JSON (ou "+envPrefix+"CONFIG)")
+
+	// Les flags sont appliqués en dernier : on les mémorise pendant l'analyse
+	type flagValue struct {
+		setting setting
+		value   string
+	}
+	var flagValues []flagValue
+	for _, s := range settings {
+		s := s
+		usage := fmt.Sprintf("%s (%s, défaut %q)", s.usage, s.env(), s.get(&cfg))
+		record := func(value string) error {
+			flagValues = append(flagValues, flagValue{s, value})
+			return nil
+		}
+		if s.boolean {
+			fs.BoolFunc(s.name, usage, record)
+		} else {
+			fs.Func(s.name, usage, record)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	if fs.NArg() > 0 {
+		return cfg, fmt.Errorf("argument inattendu : %s", fs.Arg(0))
+	}
+
+	path := *configPath
+	if path == "" {
+		path = getenv(envPrefix + "CONFIG")
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return cfg, err
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := lookupEnv(getenv, s.env()); ok {
+			if err := s.set(&cfg, value); err != nil {
+				return cfg, fmt.Errorf("%s : %v", s.env(), err)
+			}
+		}
+	}
+
+	for _, fv := range flagValues {
+		if err := fv.setting.set(&cfg, fv.value); err != nil {
+			return cfg, fmt.Errorf("-%s : %v", fv.setting.name, err)
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
+
+func lookupEnv(getenv func(string) string, key string) (string, bool) {
+	value := getenv(key)
+	return value, value != ""
+}
+
+// loadFile applique un fichier JSON de la forme {"addr": ":8080", ...}
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("fichier de configuration : %v", err)
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("fichier de configuration %s : JSON invalide : %v", path, err)
+	}
+
+	// Ordre stable, pour que la première erreur signalée soit reproductible
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s, ok := lookup(key)
+		if !ok {
+			return fmt.Errorf("fichier de configuration %s : clé inconnue %q", path, key)
+		}
+
+		var value string
+		if err := json.Unmarshal(values[key], &value); err != nil {
+			return fmt.Errorf("fichier de configuration %s : %s doit être une chaîne", path, key)
+		}
+		if err := s.set(c, value); err != nil {
+			return fmt.Errorf("fichier de configuration %s : %s : %v", path, key, err)
+		}
+	}
+	return nil
+}
+
+// Validate vérifie la cohérence de la configuration et regroupe toutes les
+// erreurs trouvées
+func (c Config) Validate() error {
+	var errs []error
+	fail := func(name, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s : %s", name, fmt.Sprintf(format, args...)))
+	}
+
+	if _, port, err := net.SplitHostPort(c.Addr); err != nil {
+		fail("addr", "adresse invalide %q (attendu hôte:port, ex. :8080)", c.Addr)
+	} else if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		fail("addr", "port invalide %q", port)
+	}
+
+	if u, err := url.Parse(c.UpstreamBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		fail("upstream-url", "URL absolue http(s) attendue, reçu %q", c.UpstreamBaseURL)
+	}
+
+	if c.RefreshInterval < 0 || (c.RefreshInterval > 0 && c.RefreshInterval < minRefreshInterval) {
+		fail("refresh-interval", "doit être 0 (désactivé) ou au moins %s, reçu %s", minRefreshInterval, c.RefreshInterval)
+	}
+
+	if c.MaxDataAge < 0 {
+		fail("max-data-age", "doit être positif ou nul, reçu %s", c.MaxDataAge)
+	}
+
+	if c.SnapshotPath != "" {
+		if info, err := os.Stat(filepath.Dir(c.SnapshotPath)); err != nil || !info.IsDir() {
+			fail("snapshot", "le répertoire %s n'existe pas", filepath.Dir(c.SnapshotPath))
+		}
+	}
+
+	for _, r := range c.TrustedProxyHeader {
+		if !(r == '-' || r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z') {
+			fail("trusted-proxy-header", "nom d'en-tête invalide %q", c.TrustedProxyHeader)
+			break
+		}
+	}
+
+	if c.PublicURL != "" {
+		if u, err := url.Parse(c.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			strings.Trim(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" {
+			fail("public-url", "origine http(s) attendue, sans chemin (ex. https://groupie.example), reçu %q", c.PublicURL)
+		}
+	}
+
+	// Les répertoires ne sont lus qu'en mode développement
+	if c.Dev {
+		for _, dir := range []struct{ name, path string }{
+			{"templates", c.TemplateDir},
+			{"static", c.StaticDir},
+		} {
+			if info, err := os.Stat(dir.path); err != nil || !info.IsDir() {
+				fail(dir.name, "répertoire introuvable %q", dir.path)
+			}
+		}
+	}
+
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"upstream-timeout", c.UpstreamTimeout},
+		{"read-timeout", c.ReadTimeout},
+		{"write-timeout", c.WriteTimeout},
+		{"idle-timeout", c.IdleTimeout},
+		{"shutdown-timeout", c.ShutdownTimeout},
+	} {
+		if timeout.value <= 0 {
+			fail(timeout.name, "doit être strictement positif, reçu %s", timeout.value)
+		}
+	}
+
+	return errors.Join(errs...)
+}
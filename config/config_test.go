@@ -0,0 +1,141 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testDirs crée les répertoires templates et static exigés par Validate
+func testDirs(t *testing.T) (string, []string) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"templates", "static"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir, []string{
+		"-templates", filepath.Join(dir, "templates"),
+		"-static", filepath.Join(dir, "static"),
+	}
+}
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	dir, dirArgs := testDirs(t)
+
+	file := filepath.Join(dir, "config.json")
+	content := `{"addr": ":9000", "refresh-interval": "30m", "upstream-timeout": "5s"}`
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	args := append([]string{"-refresh-interval", "2h"}, dirArgs...)
+	cfg, err := Load(args, env(map[string]string{
+		"GROUPIE_CONFIG":           file,
+		"GROUPIE_ADDR":             ":9100",
+		"GROUPIE_REFRESH_INTERVAL": "45m",
+	}), io.Discard)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// flag > env > fichier > défaut
+	if cfg.RefreshInterval != 2*time.Hour {
+		t.Errorf("RefreshInterval = %s; expected 2h (flag)", cfg.RefreshInterval)
+	}
+	if cfg.Addr != ":9100" {
+		t.Errorf("Addr = %s; expected :9100 (env)", cfg.Addr)
+	}
+	if cfg.UpstreamTimeout != 5*time.Second {
+		t.Errorf("UpstreamTimeout = %s; expected 5s (file)", cfg.UpstreamTimeout)
+	}
+	if cfg.UpstreamBaseURL != Default().UpstreamBaseURL {
+		t.Errorf("UpstreamBaseURL = %s; expected default", cfg.UpstreamBaseURL)
+	}
+}
+
+func TestLoadDev(t *testing.T) {
+	_, dirArgs := testDirs(t)
+
+	cfg, err := Load(append([]string{"-dev"}, dirArgs...), env(nil), io.Discard)
+	if err != nil || !cfg.Dev {
+		t.Errorf("Load(-dev) = (%v, %v); expected Dev", cfg.Dev, err)
+	}
+
+	// Hors mode développement, les répertoires ne sont pas requis
+	cfg, err = Load([]string{"-static", "/nonexistent"}, env(map[string]string{"GROUPIE_DEV": "false"}), io.Discard)
+	if err != nil || cfg.Dev {
+		t.Errorf("Load(GROUPIE_DEV=false) = (%v, %v); expected embedded assets", cfg.Dev, err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir, dirArgs := testDirs(t)
+
+	badFile := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(badFile, []byte(`{"port": "8080"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		message string
+	}{
+		{"durée invalide", []string{"-read-timeout", "10"}, nil, "-read-timeout : durée invalide"},
+		{"env invalide", nil, map[string]string{"GROUPIE_IDLE_TIMEOUT": "long"}, "GROUPIE_IDLE_TIMEOUT : durée invalide"},
+		{"clé inconnue", []string{"-config", badFile}, nil, `clé inconnue "port"`},
+		{"fichier absent", []string{"-config", filepath.Join(dir, "absent.json")}, nil, "fichier de configuration"},
+		{"adresse", []string{"-addr", "8080"}, nil, "addr : adresse invalide"},
+		{"url", []string{"-upstream-url", "groupietrackers.herokuapp.com"}, nil, "upstream-url : URL absolue"},
+		{"rafraîchissement", []string{"-refresh-interval", "1s"}, nil, "refresh-interval : doit être 0"},
+		{"âge des données", []string{"-max-data-age", "-1h"}, nil, "max-data-age : doit être positif"},
+		{"url publique", []string{"-public-url", "https://groupie.example/site"}, nil, "public-url : origine http(s) attendue"},
+		{"en-tête", []string{"-trusted-proxy-header", "X-Forwarded-For:"}, nil, "trusted-proxy-header : nom d'en-tête invalide"},
+		{"timeout nul", []string{"-write-timeout", "0s"}, nil, "write-timeout : doit être strictement positif"},
+		{"répertoire", []string{"-dev", "-static", filepath.Join(dir, "absent")}, nil, "static : répertoire introuvable"},
+		{"booléen", nil, map[string]string{"GROUPIE_DEV": "oui"}, "GROUPIE_DEV : booléen invalide"},
+		{"snapshot", []string{"-snapshot", filepath.Join(dir, "absent", "data.json")}, nil, "snapshot : le répertoire"},
+	}
+
+	for _, test := range tests {
+		args := append(append([]string{}, dirArgs...), test.args...)
+		_, err := Load(args, env(test.env), io.Discard)
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("%s: Load() error = %v; expected %q", test.name, err, test.message)
+		}
+	}
+
+	if _, err := Load([]string{"-h"}, env(nil), io.Discard); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("Load(-h) error = %v; expected flag.ErrHelp", err)
+	}
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+	cfg := Default()
+	cfg.Addr = "nope"
+	cfg.UpstreamTimeout = 0
+	cfg.TemplateDir = "/nonexistent"
+	cfg.Dev = true
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil; expected errors")
+	}
+	for _, name := range []string{"addr", "upstream-timeout", "templates"} {
+		if !strings.Contains(err.Error(), name+" :") {
+			t.Errorf("Validate() = %v; expected an error for %s", err, name)
+		}
+	}
+}
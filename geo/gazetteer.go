@@ -0,0 +1,321 @@
+package geo
+
+// places associe les locations de l'API ("ville-pays", "etat-usa", ...)
+// à leurs coordonnées. Le répertoire est embarqué pour que le serveur puisse
+// géocoder sans service externe.
+var places = map[string]Point{
+	// Amérique du Nord - villes
+	"new_york-usa":            {40.7128, -74.0060},
+	"los_angeles-usa":         {34.0522, -118.2437},
+	"san_francisco-usa":       {37.7749, -122.4194},
+	"oakland-usa":             {37.8044, -122.2712},
+	"anaheim-usa":             {33.8366, -117.9143},
+	"del_mar-usa":             {32.9595, -117.2653},
+	"indio-usa":               {33.7206, -116.2156},
+	"san_diego-usa":           {32.7157, -117.1611},
+	"las_vegas-usa":           {36.1699, -115.1398},
+	"phoenix-usa":             {33.4484, -112.0740},
+	"salt_lake_city-usa":      {40.7608, -111.8910},
+	"denver-usa":              {39.7392, -104.9903},
+	"seattle-usa":             {47.6062, -122.3321},
+	"portland-usa":            {45.5152, -122.6784},
+	"chicago-usa":             {41.8781, -87.6298},
+	"detroit-usa":             {42.3314, -83.0458},
+	"cleveland-usa":           {41.4993, -81.6944},
+	"pittsburgh-usa":          {40.4406, -79.9959},
+	"philadelphia-usa":        {39.9526, -75.1652},
+	"boston-usa":              {42.3601, -71.0589},
+	"washington-usa":          {38.9072, -77.0369},
+	"atlanta-usa":             {33.7490, -84.3880},
+	"miami-usa":               {25.7617, -80.1918},
+	"west_melbourne-usa":      {28.0717, -80.6534},
+	"orlando-usa":             {28.5383, -81.3792},
+	"houston-usa":             {29.7604, -95.3698},
+	"dallas-usa":              {32.7767, -96.7970},
+	"austin-usa":              {30.2672, -97.7431},
+	"san_antonio-usa":         {29.4241, -98.4936},
+	"nashville-usa":           {36.1627, -86.7816},
+	"new_orleans-usa":         {29.9511, -90.0715},
+	"minneapolis-usa":         {44.9778, -93.2650},
+	"st_louis-usa":            {38.6270, -90.1994},
+	"kansas_city-usa":         {39.0997, -94.5786},
+	"birmingham-usa":          {33.5186, -86.8104},
+	"charlotte-usa":           {35.2271, -80.8431},
+	"toronto-canada":          {43.6532, -79.3832},
+	"montreal-canada":         {45.5017, -73.5673},
+	"quebec-canada":           {46.8139, -71.2080},
+	"ottawa-canada":           {45.4215, -75.6972},
+	"vancouver-canada":        {49.2827, -123.1207},
+	"calgary-canada":          {51.0447, -114.0719},
+	"edmonton-canada":         {53.5461, -113.4938},
+	"winnipeg-canada":         {49.8951, -97.1384},
+	"mexico_city-mexico":      {19.4326, -99.1332},
+	"monterrey-mexico":        {25.6866, -100.3161},
+	"guadalajara-mexico":      {20.6597, -103.3496},
+	"zapopan-mexico":          {20.7236, -103.3848},
+	"puebla-mexico":           {19.0414, -98.2063},
+	"playa_del_carmen-mexico": {20.6296, -87.0739},
+	"san_jose-costa_rica":     {9.9281, -84.0907},
+	"sanjose-costa_rica":      {9.9281, -84.0907},
+
+	// Amérique du Nord - états et provinces
+	"alabama-usa":             {32.8067, -86.7911},
+	"arizona-usa":             {34.0489, -111.0937},
+	"california-usa":          {36.7783, -119.4179},
+	"colorado-usa":            {39.5501, -105.7821},
+	"delaware-usa":            {38.9108, -75.5277},
+	"florida-usa":             {27.6648, -81.5158},
+	"georgia-usa":             {32.1656, -82.9001},
+	"illinois-usa":            {40.6331, -89.3985},
+	"maryland-usa":            {39.0458, -76.6413},
+	"massachusetts-usa":       {42.4072, -71.3824},
+	"michigan-usa":            {44.3148, -85.6024},
+	"minnesota-usa":           {46.7296, -94.6859},
+	"missouri-usa":            {37.9643, -91.8318},
+	"nevada-usa":              {38.8026, -116.4194},
+	"new_jersey-usa":          {40.0583, -74.4057},
+	"north_carolina-usa":      {35.7596, -79.0193},
+	"ohio-usa":                {40.4173, -82.9071},
+	"oregon-usa":              {43.8041, -120.5542},
+	"pennsylvania-usa":        {41.2033, -77.1945},
+	"south_carolina-usa":      {33.8361, -81.1637},
+	"texas-usa":               {31.9686, -99.9018},
+	"utah-usa":                {39.3210, -111.0937},
+	"virginia-usa":            {37.4316, -78.6569},
+	"alberta-canada":          {53.9333, -116.5765},
+	"british_columbia-canada": {53.7267, -127.6476},
+	"manitoba-canada":         {53.7609, -98.8139},
+	"new_brunswick-canada":    {46.5653, -66.4619},
+	"nova_scotia-canada":      {44.6820, -63.7443},
+	"ontario-canada":          {51.2538, -85.3232},
+	"saskatchewan-canada":     {52.9399, -106.4509},
+
+	// Amérique du Sud
+	"sao_paulo-brazil":       {-23.5505, -46.6333},
+	"rio_de_janeiro-brazil":  {-22.9068, -43.1729},
+	"belo_horizonte-brazil":  {-19.9167, -43.9345},
+	"porto_alegre-brazil":    {-30.0346, -51.2177},
+	"curitiba-brazil":        {-25.4284, -49.2733},
+	"brasilia-brazil":        {-15.7975, -47.8919},
+	"recife-brazil":          {-8.0476, -34.8770},
+	"salvador-brazil":        {-12.9777, -38.5016},
+	"fortaleza-brazil":       {-3.7319, -38.5267},
+	"buenos_aires-argentina": {-34.6037, -58.3816},
+	"la_plata-argentina":     {-34.9215, -57.9545},
+	"rosario-argentina":      {-32.9442, -60.6505},
+	"cordoba-argentina":      {-31.4201, -64.1888},
+	"santiago-chile":         {-33.4489, -70.6693},
+	"lima-peru":              {-12.0464, -77.0428},
+	"bogota-colombia":        {4.7110, -74.0721},
+	"quito-ecuador":          {-0.1807, -78.4678},
+	"caracas-venezuela":      {10.4806, -66.9036},
+	"montevideo-uruguay":     {-34.9011, -56.1645},
+	"asuncion-paraguay":      {-25.2637, -57.5759},
+
+	// Europe
+	"london-uk":               {51.5074, -0.1278},
+	"manchester-uk":           {53.4808, -2.2426},
+	"birmingham-uk":           {52.4862, -1.8904},
+	"liverpool-uk":            {53.4084, -2.9916},
+	"leeds-uk":                {53.8008, -1.5491},
+	"sheffield-uk":            {53.3811, -1.4701},
+	"glasgow-uk":              {55.8642, -4.2518},
+	"edinburgh-uk":            {55.9533, -3.1883},
+	"cardiff-uk":              {51.4816, -3.1791},
+	"dublin-ireland":          {53.3498, -6.2603},
+	"paris-france":            {48.8566, 2.3522},
+	"lyon-france":             {45.7640, 4.8357},
+	"marseille-france":        {43.2965, 5.3698},
+	"toulouse-france":         {43.6047, 1.4442},
+	"bordeaux-france":         {44.8378, -0.5792},
+	"nantes-france":           {47.2184, -1.5536},
+	"lille-france":            {50.6292, 3.0573},
+	"strasbourg-france":       {48.5734, 7.7521},
+	"nice-france":             {43.7102, 7.2620},
+	"montpellier-france":      {43.6108, 3.8767},
+	"nimes-france":            {43.8367, 4.3601},
+	"arras-france":            {50.2910, 2.7775},
+	"carhaix-france":          {48.2757, -3.5737},
+	"clermont_ferrand-france": {45.7772, 3.0870},
+	"brussels-belgium":        {50.8503, 4.3517},
+	"antwerp-belgium":         {51.2194, 4.4025},
+	"werchter-belgium":        {50.9711, 4.7008},
+	"amsterdam-netherlands":   {52.3676, 4.9041},
+	"rotterdam-netherlands":   {51.9244, 4.4777},
+	"landgraaf-netherlands":   {50.8910, 6.0230},
+	"luxembourg-luxembourg":   {49.6116, 6.1319},
+	"berlin-germany":          {52.5200, 13.4050},
+	"hamburg-germany":         {53.5511, 9.9937},
+	"munich-germany":          {48.1351, 11.5820},
+	"frankfurt-germany":       {50.1109, 8.6821},
+	"dusseldorf-germany":      {51.2277, 6.7735},
+	"cologne-germany":         {50.9375, 6.9603},
+	"stuttgart-germany":       {48.7758, 9.1829},
+	"mannheim-germany":        {49.4875, 8.4660},
+	"leipzig-germany":         {51.3397, 12.3731},
+	"zurich-switzerland":      {47.3769, 8.5417},
+	"lausanne-switzerland":    {46.5197, 6.6323},
+	"geneva-switzerland":      {46.2044, 6.1432},
+	"vienna-austria":          {48.2082, 16.3738},
+	"graz-austria":            {47.0707, 15.4395},
+	"madrid-spain":            {40.4168, -3.7038},
+	"barcelona-spain":         {41.3851, 2.1734},
+	"bilbao-spain":            {43.2630, -2.9350},
+	"zaragoza-spain":          {41.6488, -0.8891},
+	"valencia-spain":          {39.4699, -0.3763},
+	"lisbon-portugal":         {38.7223, -9.1393},
+	"porto-portugal":          {41.1579, -8.6291},
+	"milan-italy":             {45.4642, 9.1900},
+	"rome-italy":              {41.9028, 12.4964},
+	"florence-italy":          {43.7696, 11.2558},
+	"bologna-italy":           {44.4949, 11.3426},
+	"copenhagen-denmark":      {55.6761, 12.5683},
+	"aarhus-denmark":          {56.1629, 10.2039},
+	"aalborg-denmark":         {57.0488, 9.9217},
+	"roskilde-denmark":        {55.6419, 12.0878},
+	"skanderborg-denmark":     {56.0396, 9.9272},
+	"stockholm-sweden":        {59.3293, 18.0686},
+	"gothenburg-sweden":       {57.7089, 11.9746},
+	"oslo-norway":             {59.9139, 10.7522},
+	"helsinki-finland":        {60.1699, 24.9384},
+	"warsaw-poland":           {52.2297, 21.0122},
+	"krakow-poland":           {50.0647, 19.9450},
+	"gdansk-poland":           {54.3520, 18.6466},
+	"lodz-poland":             {51.7592, 19.4560},
+	"katowice-poland":         {50.2649, 19.0238},
+	"prague-czechia":          {50.0755, 14.4378},
+	"bratislava-slovakia":     {48.1486, 17.1077},
+	"budapest-hungary":        {47.4979, 19.0402},
+	"ljubljana-slovenia":      {46.0569, 14.5058},
+	"zagreb-croatia":          {45.8150, 15.9819},
+	"belgrade-serbia":         {44.7866, 20.4489},
+	"bucharest-romania":       {44.4268, 26.1025},
+	"sofia-bulgaria":          {42.6977, 23.3219},
+	"athens-greece":           {37.9838, 23.7275},
+	"thessaloniki-greece":     {40.6401, 22.9444},
+	"istanbul-turkey":         {41.0082, 28.9784},
+	"minsk-belarus":           {53.9006, 27.5590},
+	"kiev-ukraine":            {50.4501, 30.5234},
+	"moscow-russia":           {55.7558, 37.6173},
+	"saint_petersburg-russia": {59.9311, 30.3609},
+	"riga-latvia":             {56.9496, 24.1052},
+	"vilnius-lithuania":       {54.6872, 25.2797},
+	"tallinn-estonia":         {59.4370, 24.7536},
+
+	// Afrique et Moyen-Orient
+	"johannesburg-south_africa":      {-26.2041, 28.0473},
+	"cape_town-south_africa":         {-33.9249, 18.4241},
+	"pretoria-south_africa":          {-25.7479, 28.2293},
+	"abu_dhabi-united_arab_emirates": {24.4539, 54.3773},
+	"dubai-united_arab_emirates":     {25.2048, 55.2708},
+	"doha-qatar":                     {25.2854, 51.5310},
+	"tel_aviv-israel":                {32.0853, 34.7818},
+
+	// Asie
+	"tokyo-japan":           {35.6762, 139.6503},
+	"osaka-japan":           {34.6937, 135.5023},
+	"nagoya-japan":          {35.1815, 136.9066},
+	"saitama-japan":         {35.8617, 139.6455},
+	"chiba-japan":           {35.6074, 140.1065},
+	"seoul-south_korea":     {37.5665, 126.9780},
+	"beijing-china":         {39.9042, 116.4074},
+	"shanghai-china":        {31.2304, 121.4737},
+	"canton-china":          {23.1291, 113.2644},
+	"hong_kong-china":       {22.3193, 114.1694},
+	"taipei-taiwan":         {25.0330, 121.5654},
+	"manila-philippines":    {14.5995, 120.9842},
+	"bangkok-thailand":      {13.7563, 100.5018},
+	"kuala_lumpur-malaysia": {3.1390, 101.6869},
+	"singapore-singapore":   {1.3521, 103.8198},
+	"jakarta-indonesia":     {-6.2088, 106.8456},
+	"yogyakarta-indonesia":  {-7.7956, 110.3695},
+	"mumbai-india":          {19.0760, 72.8777},
+	"new_delhi-india":       {28.6139, 77.2090},
+
+	// Océanie
+	"sydney-australia":          {-33.8688, 151.2093},
+	"melbourne-australia":       {-37.8136, 144.9631},
+	"brisbane-australia":        {-27.4698, 153.0251},
+	"perth-australia":           {-31.9505, 115.8605},
+	"adelaide-australia":        {-34.9285, 138.6007},
+	"new_south_wales-australia": {-31.2532, 146.9211},
+	"victoria-australia":        {-36.9848, 143.3906},
+	"queensland-australia":      {-20.9176, 142.7028},
+	"auckland-new_zealand":      {-36.8485, 174.7633},
+	"wellington-new_zealand":    {-41.2865, 174.7762},
+	"christchurch-new_zealand":  {-43.5321, 172.6362},
+	"dunedin-new_zealand":       {-45.8788, 170.5028},
+	"penrose-new_zealand":       {-36.9095, 174.8155},
+	"papeete-french_polynesia":  {-17.5516, -149.5585},
+	"noumea-new_caledonia":      {-22.2758, 166.4580},
+}
+
+// countries donne un centre approximatif par pays, utilisé quand la ville
+// n'est pas dans le répertoire
+var countries = map[string]Point{
+	"usa":                  {39.8283, -98.5795},
+	"canada":               {56.1304, -106.3468},
+	"mexico":               {23.6345, -102.5528},
+	"costa_rica":           {9.7489, -83.7534},
+	"brazil":               {-14.2350, -51.9253},
+	"argentina":            {-38.4161, -63.6167},
+	"chile":                {-35.6751, -71.5430},
+	"peru":                 {-9.1900, -75.0152},
+	"colombia":             {4.5709, -74.2973},
+	"ecuador":              {-1.8312, -78.1834},
+	"venezuela":            {6.4238, -66.5897},
+	"uruguay":              {-32.5228, -55.7658},
+	"paraguay":             {-23.4425, -58.4438},
+	"uk":                   {54.7024, -3.2766},
+	"ireland":              {53.4129, -8.2439},
+	"france":               {46.2276, 2.2137},
+	"belgium":              {50.5039, 4.4699},
+	"netherlands":          {52.1326, 5.2913},
+	"luxembourg":           {49.8153, 6.1296},
+	"germany":              {51.1657, 10.4515},
+	"switzerland":          {46.8182, 8.2275},
+	"austria":              {47.5162, 14.5501},
+	"spain":                {40.4637, -3.7492},
+	"portugal":             {39.3999, -8.2245},
+	"italy":                {41.8719, 12.5674},
+	"denmark":              {56.2639, 9.5018},
+	"sweden":               {60.1282, 18.6435},
+	"norway":               {60.4720, 8.4689},
+	"finland":              {61.9241, 25.7482},
+	"poland":               {51.9194, 19.1451},
+	"czechia":              {49.8175, 15.4730},
+	"czech_republic":       {49.8175, 15.4730},
+	"slovakia":             {48.6690, 19.6990},
+	"hungary":              {47.1625, 19.5033},
+	"slovenia":             {46.1512, 14.9955},
+	"croatia":              {45.1000, 15.2000},
+	"serbia":               {44.0165, 21.0059},
+	"romania":              {45.9432, 24.9668},
+	"bulgaria":             {42.7339, 25.4858},
+	"greece":               {39.0742, 21.8243},
+	"turkey":               {38.9637, 35.2433},
+	"belarus":              {53.7098, 27.9534},
+	"ukraine":              {48.3794, 31.1656},
+	"russia":               {55.7558, 37.6173},
+	"latvia":               {56.8796, 24.6032},
+	"lithuania":            {55.1694, 23.8813},
+	"estonia":              {58.5953, 25.0136},
+	"south_africa":         {-30.5595, 22.9375},
+	"united_arab_emirates": {23.4241, 53.8478},
+	"qatar":                {25.3548, 51.1839},
+	"israel":               {31.0461, 34.8516},
+	"japan":                {36.2048, 138.2529},
+	"south_korea":          {35.9078, 127.7669},
+	"china":                {35.8617, 104.1954},
+	"taiwan":               {23.6978, 120.9605},
+	"philippines":          {12.8797, 121.7740},
+	"thailand":             {15.8700, 100.9925},
+	"malaysia":             {4.2105, 101.9758},
+	"singapore":            {1.3521, 103.8198},
+	"indonesia":            {-0.7893, 113.9213},
+	"india":                {20.5937, 78.9629},
+	"australia":            {-25.2744, 133.7751},
+	"new_zealand":          {-40.9006, 174.8860},
+	"french_polynesia":     {-17.6797, -149.4068},
+	"new_caledonia":        {-20.9043, 165.6180},
+}
@@ -0,0 +1,52 @@
+package geo
+
+import (
+	"math"
+	"strings"
+)
+
+// EarthRadiusKm est le rayon moyen de la Terre utilisé pour les distances
+const EarthRadiusKm = 6371.0
+
+// Point représente une position géographique en degrés décimaux
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Valid vérifie que le point est dans les bornes latitude/longitude
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+// Distance retourne la distance orthodromique entre deux points en kilomètres (formule de haversine)
+func Distance(a, b Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := (b.Lat - a.Lat) * math.Pi / 180
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Lookup géocode une location au format de l'API ("ville-pays") à partir
+// du répertoire embarqué. Si la ville est inconnue, le centre du pays est
+// retourné et exact vaut false.
+func Lookup(location string) (p Point, exact bool, ok bool) {
+	key := strings.ToLower(strings.TrimSpace(location))
+
+	if p, found := places[key]; found {
+		return p, true, true
+	}
+
+	if i := strings.LastIndex(key, "-"); i >= 0 {
+		if p, found := countries[key[i+1:]]; found {
+			return p, false, true
+		}
+	}
+
+	return Point{}, false, false
+}
@@ -0,0 +1,139 @@
+package geo
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	paris := Point{48.8566, 2.3522}
+	lyon := Point{45.7640, 4.8357}
+
+	d := Distance(paris, lyon)
+	if math.Abs(d-392) > 5 {
+		t.Errorf("Distance(Paris, Lyon) = %.1f km; expected ~392 km", d)
+	}
+
+	if d := Distance(paris, paris); d != 0 {
+		t.Errorf("Distance(Paris, Paris) = %f; expected 0", d)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		location string
+		exact    bool
+		ok       bool
+	}{
+		{"lyon-france", true, true},
+		{"LYON-FRANCE", true, true},
+		{"north_carolina-usa", true, true},
+		{"unknown_town-france", false, true},
+		{"nowhere-atlantis", false, false},
+		{"", false, false},
+	}
+
+	for _, test := range tests {
+		_, exact, ok := Lookup(test.location)
+		if exact != test.exact || ok != test.ok {
+			t.Errorf("Lookup(%s) = (%v, %v); expected (%v, %v)",
+				test.location, exact, ok, test.exact, test.ok)
+		}
+	}
+}
+
+func TestIndexWithin(t *testing.T) {
+	idx := NewIndex(2)
+	idx.Insert(Point{45.7640, 4.8357}, 1)     // Lyon
+	idx.Insert(Point{46.2044, 6.1432}, 2)     // Genève
+	idx.Insert(Point{48.8566, 2.3522}, 3)     // Paris
+	idx.Insert(Point{-17.5516, -149.5585}, 4) // Papeete
+	idx.Insert(Point{-22.2758, 166.4580}, 5)  // Nouméa
+
+	ids := func(matches []Match) []int {
+		var out []int
+		for _, m := range matches {
+			out = append(out, m.ID)
+		}
+		sort.Ints(out)
+		return out
+	}
+
+	got := ids(idx.Within(Point{45.7640, 4.8357}, 200))
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Within(Lyon, 200km) = %v; expected [1 2]", got)
+	}
+
+	got = ids(idx.Within(Point{45.7640, 4.8357}, 500))
+	if len(got) != 3 {
+		t.Errorf("Within(Lyon, 500km) = %v; expected [1 2 3]", got)
+	}
+
+	// Recherche à cheval sur l'antiméridien
+	got = ids(idx.Within(Point{-20, -179.5}, 2000))
+	if len(got) != 1 || got[0] != 5 {
+		t.Errorf("Within(antiméridien, 2000km) = %v; expected [5]", got)
+	}
+
+	if idx.Len() != 5 {
+		t.Errorf("Len() = %d; expected 5", idx.Len())
+	}
+}
+
+func TestWorldMapSVG(t *testing.T) {
+	markers := []Marker{
+		{Point: Point{51.5074, -0.1278}, Title: `London <script>"`, Href: "/location/london-uk?a=1&b=2"},
+		{Point: Point{48.8566, 2.3522}, Title: "Paris", Approximate: true},
+	}
+	svg := WorldMapSVG("Queen & co", markers)
+
+	if strings.Contains(svg, "<script>") || !strings.Contains(svg, "London &lt;script&gt;&#34;") {
+		t.Error("marker title was not escaped")
+	}
+	if !strings.Contains(svg, `href="/location/london-uk?a=1&amp;b=2"`) {
+		t.Error("marker link missing or not escaped")
+	}
+	if n := strings.Count(svg, "<circle"); n != len(markers) {
+		t.Errorf("%d markers drawn; expected %d", n, len(markers))
+	}
+	if n := strings.Count(svg, "<a "); n != 1 {
+		t.Errorf("%d links; expected 1", n)
+	}
+
+	// Le SVG doit être du XML bien formé
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+	}
+}
+
+func TestWorldMapFrame(t *testing.T) {
+	tests := []struct {
+		name    string
+		markers []Marker
+	}{
+		{"none", nil},
+		{"single", []Marker{{Point: Point{48.8566, 2.3522}}}},
+		{"far north", []Marker{{Point: Point{64.1466, -21.9426}}}},
+		{"world", []Marker{{Point: Point{-36.8485, 174.7633}}, {Point: Point{61.2181, -149.9003}}}},
+	}
+	for _, tt := range tests {
+		x, y, w, h := frame(tt.markers)
+		if w < mapMinWidth || w > 360 || h <= 0 || x < -180 || x+w > 180 || -y > mapNorth || -(y+h) < mapSouth-0.001 {
+			t.Errorf("%s: frame = (%v, %v, %v, %v) out of the map", tt.name, x, y, w, h)
+		}
+		for _, m := range tt.markers {
+			if m.Lon < x || m.Lon > x+w || -m.Lat < y || -m.Lat > y+h {
+				t.Errorf("%s: marker %+v outside the frame", tt.name, m.Point)
+			}
+		}
+	}
+}
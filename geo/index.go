@@ -0,0 +1,113 @@
+package geo
+
+import "math"
+
+// Index est un index spatial en grille : chaque entrée est rangée dans une
+// cellule de CellSize degrés, ce qui permet de ne tester que les cellules
+// couvrant le rayon de recherche.
+type Index struct {
+	cellSize float64
+	cells    map[cell][]entry
+	size     int
+}
+
+type cell struct {
+	lat, lon int
+}
+
+type entry struct {
+	point Point
+	id    int
+}
+
+// Match est un résultat de recherche : l'identifiant inséré et sa distance au centre
+type Match struct {
+	ID         int
+	DistanceKm float64
+}
+
+// NewIndex crée un index dont les cellules mesurent cellSize degrés
+func NewIndex(cellSize float64) *Index {
+	if cellSize <= 0 {
+		cellSize = 1
+	}
+	return &Index{
+		cellSize: cellSize,
+		cells:    make(map[cell][]entry),
+	}
+}
+
+// Len retourne le nombre d'entrées de l'index
+func (idx *Index) Len() int {
+	return idx.size
+}
+
+// Insert ajoute un identifiant à la position donnée
+func (idx *Index) Insert(p Point, id int) {
+	c := idx.cellOf(p)
+	idx.cells[c] = append(idx.cells[c], entry{point: p, id: id})
+	idx.size++
+}
+
+// Within retourne les entrées situées à moins de radiusKm du centre
+func (idx *Index) Within(center Point, radiusKm float64) []Match {
+	var matches []Match
+	if radiusKm < 0 {
+		return matches
+	}
+
+	// Étendue en degrés du rayon : la latitude est constante,
+	// la longitude s'élargit en s'approchant des pôles
+	dLat := radiusKm / (EarthRadiusKm * math.Pi / 180)
+	minLat := math.Max(-90, center.Lat-dLat)
+	maxLat := math.Min(90, center.Lat+dLat)
+
+	dLon := 180.0
+	if cosLat := math.Min(math.Cos(minLat*math.Pi/180), math.Cos(maxLat*math.Pi/180)); cosLat > 1e-6 {
+		dLon = dLat / cosLat
+	}
+	wrap := dLon >= 180-idx.cellSize || minLat == -90 || maxLat == 90
+
+	latStart, latEnd := idx.coord(minLat), idx.coord(maxLat)
+	for la := latStart; la <= latEnd; la++ {
+		if wrap {
+			for c, entries := range idx.cells {
+				if c.lat == la {
+					matches = appendWithin(matches, entries, center, radiusKm)
+				}
+			}
+			continue
+		}
+
+		lonStart, lonEnd := idx.coord(center.Lon-dLon), idx.coord(center.Lon+dLon)
+		for lo := lonStart; lo <= lonEnd; lo++ {
+			matches = appendWithin(matches, idx.cells[cell{la, idx.wrapLon(lo)}], center, radiusKm)
+		}
+	}
+
+	return matches
+}
+
+func appendWithin(matches []Match, entries []entry, center Point, radiusKm float64) []Match {
+	for _, e := range entries {
+		if d := Distance(center, e.point); d <= radiusKm {
+			matches = append(matches, Match{ID: e.id, DistanceKm: d})
+		}
+	}
+	return matches
+}
+
+func (idx *Index) cellOf(p Point) cell {
+	return cell{lat: idx.coord(p.Lat), lon: idx.wrapLon(idx.coord(p.Lon))}
+}
+
+func (idx *Index) coord(deg float64) int {
+	return int(math.Floor(deg / idx.cellSize))
+}
+
+// wrapLon ramène un numéro de colonne dans [-180°, 180°[ pour traverser l'antiméridien
+func (idx *Index) wrapLon(c int) int {
+	n := int(math.Ceil(360 / idx.cellSize))
+	first := idx.coord(-180)
+	return ((c-first)%n+n)%n + first
+}
@@ -0,0 +1,106 @@
+package geo
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+// Marker est un point à placer sur la carte du monde
+type Marker struct {
+	Point
+	Title       string // infobulle du marqueur
+	Href        string // lien du marqueur, optionnel
+	Approximate bool   // position connue au pays près
+}
+
+// Cadre de la carte : les régions polaires sont coupées
+const (
+	mapNorth = 84.0
+	mapSouth = -58.0
+
+	mapPadding  = 8.0  // marge autour des marqueurs, en degrés
+	mapMinWidth = 60.0 // largeur minimale du cadrage, en degrés
+)
+
+// WorldMapSVG dessine la carte du monde en projection équirectangulaire
+// (x = longitude, y = -latitude), cadrée sur les marqueurs avec un rapport
+// 2:1. Le SVG est autonome : aucun script ni service externe. Les titres et
+// liens sont échappés.
+func WorldMapSVG(title string, markers []Marker) string {
+	x, y, w, h := frame(markers)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" class="world-map" viewBox="%s %s %s %s" preserveAspectRatio="xMidYMid meet" role="img">`,
+		coord(x), coord(y), coord(w), coord(h))
+	fmt.Fprintf(&b, `<title>%s</title>`, html.EscapeString(title))
+
+	b.WriteString(`<path class="world-land" d="`)
+	for _, polygon := range worldOutline {
+		for i, p := range polygon {
+			if i == 0 {
+				b.WriteString("M")
+			} else {
+				b.WriteString("L")
+			}
+			b.WriteString(coord(p.Lon) + " " + coord(-p.Lat))
+		}
+		b.WriteString("Z")
+	}
+	b.WriteString(`"/>`)
+
+	// Taille des marqueurs proportionnelle au cadrage
+	r := w / 90
+	b.WriteString(`<g class="world-markers">`)
+	for _, m := range markers {
+		class := "world-marker"
+		if m.Approximate {
+			class += " approximate"
+		}
+		circle := fmt.Sprintf(`<circle class="%s" cx="%s" cy="%s" r="%s" stroke-width="%s"><title>%s</title></circle>`,
+			class, coord(m.Lon), coord(-m.Lat), coord(r), coord(r/3), html.EscapeString(m.Title))
+		if m.Href != "" {
+			circle = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(m.Href), circle)
+		}
+		b.WriteString(circle)
+	}
+	b.WriteString(`</g></svg>`)
+
+	return b.String()
+}
+
+// frame retourne le cadre (x, y, largeur, hauteur) englobant les marqueurs,
+// ou le monde entier s'il n'y en a aucun
+func frame(markers []Marker) (x, y, w, h float64) {
+	if len(markers) == 0 {
+		return -180, -mapNorth, 360, mapNorth - mapSouth
+	}
+
+	west, east := 180.0, -180.0
+	south, north := 90.0, -90.0
+	for _, m := range markers {
+		west, east = math.Min(west, m.Lon), math.Max(east, m.Lon)
+		south, north = math.Min(south, m.Lat), math.Max(north, m.Lat)
+	}
+
+	w = math.Max(east-west+2*mapPadding, mapMinWidth)
+	h = north - south + 2*mapPadding
+	w = math.Max(w, 2*h)
+	w = math.Min(w, 360)
+	h = math.Min(w/2, mapNorth-mapSouth)
+
+	// Centré sur les marqueurs puis ramené dans les limites de la carte
+	x = clamp((west+east)/2-w/2, -180, 180-w)
+	top := clamp((north+south)/2+h/2, mapSouth+h, mapNorth)
+	return x, -top, w, h
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(v, hi))
+}
+
+// coord formate une coordonnée SVG au dixième de degré
+func coord(v float64) string {
+	return strings.TrimSuffix(strings.TrimSuffix(fmt.Sprintf("%.1f", v), "0"), ".")
+}
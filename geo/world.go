@@ -0,0 +1,114 @@
+package geo
+
+// worldOutline est un tracé simplifié des terres émergées (une centaine de
+// kilomètres de précision), embarqué pour dessiner la carte du monde sans
+// service externe. Chaque polygone est une suite de points {lat, lon}.
+var worldOutline = [][]Point{
+	// Amérique du Nord et centrale
+	{
+		{71, -156}, {70, -141}, {69, -133}, {70, -128}, {68, -115}, {68, -108}, {66, -95},
+		{69, -90}, {66, -85}, {62, -94}, {58, -94}, {55, -82}, {51, -79}, {55, -77},
+		{59, -78}, {62, -78}, {60, -70}, {58, -68}, {60, -64}, {55, -60}, {52, -56},
+		{47, -53}, {46, -60}, {44, -66}, {42, -70}, {41, -74}, {37, -76}, {35, -76},
+		{32, -81}, {30, -81}, {25, -80}, {27, -82}, {30, -84}, {30, -89}, {29, -94},
+		{26, -97}, {22, -98}, {19, -96}, {18, -94}, {21, -90}, {21, -87}, {16, -88},
+		{15, -84}, {11, -84}, {9, -82}, {9, -78}, {7, -78}, {8, -80}, {8, -83},
+		{11, -86}, {13, -88}, {15, -93}, {16, -96}, {18, -103}, {21, -105}, {23, -106},
+		{27, -110}, {31, -113}, {31, -115}, {27, -112}, {23, -110}, {28, -115}, {32, -117},
+		{34, -120}, {38, -123}, {42, -124}, {46, -124}, {49, -125}, {52, -128}, {55, -131},
+		{58, -136}, {60, -141}, {60, -147}, {59, -152}, {57, -157}, {55, -162}, {59, -162},
+		{61, -166}, {64, -165}, {66, -168}, {68, -166},
+	},
+	// Cuba
+	{{23, -84}, {23, -80}, {21, -75}, {20, -74}, {20, -77}, {22, -80}},
+	// Groenland
+	{
+		{60, -43}, {65, -40}, {70, -22}, {77, -18}, {82, -20}, {83, -35}, {82, -60},
+		{78, -72}, {76, -68}, {70, -55}, {65, -52}, {60, -46},
+	},
+	// Islande
+	{{64, -22}, {66, -23}, {66, -15}, {65, -13}, {64, -15}, {63, -19}},
+	// Amérique du Sud
+	{
+		{12, -72}, {11, -64}, {10, -62}, {8, -60}, {6, -57}, {5, -52}, {2, -50},
+		{0, -50}, {-1, -48}, {-3, -40}, {-5, -36}, {-8, -35}, {-13, -38}, {-18, -39},
+		{-23, -41}, {-25, -47}, {-29, -49}, {-33, -52}, {-35, -55}, {-36, -57}, {-39, -62},
+		{-41, -63}, {-42, -65}, {-46, -67}, {-48, -66}, {-51, -69}, {-53, -68}, {-55, -66},
+		{-55, -70}, {-53, -74}, {-47, -75}, {-42, -74}, {-37, -73}, {-30, -71}, {-24, -70},
+		{-18, -70}, {-14, -76}, {-6, -81}, {-2, -80}, {1, -79}, {4, -77}, {8, -77},
+		{9, -76}, {11, -74},
+	},
+	// Grande-Bretagne
+	{
+		{50, -5}, {51, 1}, {53, 0}, {55, -2}, {57, -2}, {58, -3}, {58, -5}, {57, -6},
+		{55, -5}, {54, -3}, {53, -4}, {52, -4}, {51, -5},
+	},
+	// Irlande
+	{{52, -10}, {54, -10}, {55, -7}, {54, -6}, {52, -6}, {51, -9}},
+	// Eurasie
+	{
+		{37, -9}, {43, -9}, {43, -2}, {46, -1}, {48, -5}, {49, 0}, {51, 2}, {53, 5},
+		{54, 8}, {57, 8}, {57, 10}, {55, 10}, {54, 11}, {54, 14}, {55, 19}, {57, 21},
+		{59, 23}, {60, 29}, {60, 25}, {60, 22}, {63, 22}, {65, 25}, {66, 24}, {65, 22},
+		{63, 19}, {60, 18}, {58, 17}, {56, 16}, {55, 13}, {57, 12}, {59, 11}, {58, 7},
+		{59, 5}, {62, 5}, {64, 10}, {67, 14}, {69, 17}, {70, 21}, {71, 26}, {70, 30},
+		{69, 33}, {67, 41}, {68, 44}, {68, 54}, {69, 60}, {70, 67}, {73, 70}, {72, 80},
+		{74, 87}, {76, 98}, {77, 105}, {74, 113}, {73, 127}, {72, 140}, {71, 150},
+		{70, 160}, {69, 170}, {66, 180}, {62, 179}, {60, 170}, {60, 163}, {56, 162},
+		{51, 157}, {57, 156}, {59, 152}, {59, 143}, {54, 140}, {53, 141}, {47, 138},
+		{43, 135}, {42, 131}, {39, 128}, {35, 129}, {35, 126}, {38, 126}, {39, 125},
+		{40, 122}, {39, 118}, {37, 119}, {37, 122}, {35, 120}, {31, 122}, {27, 120},
+		{23, 117}, {22, 113}, {21, 110}, {21, 106}, {17, 107}, {12, 109}, {10, 106},
+		{9, 105}, {10, 104}, {13, 100}, {10, 99}, {7, 100}, {3, 103}, {1, 104},
+		{3, 101}, {6, 100}, {8, 98}, {10, 98}, {16, 97}, {17, 95}, {21, 92}, {22, 89},
+		{20, 86}, {16, 81}, {13, 80}, {10, 80}, {8, 77}, {11, 75}, {16, 73}, {20, 73},
+		{22, 70}, {24, 67}, {25, 62}, {27, 56}, {30, 50}, {29, 48}, {26, 50}, {24, 52},
+		{26, 56}, {22, 60}, {19, 57}, {17, 55}, {15, 52}, {13, 45}, {16, 43}, {20, 40},
+		{24, 38}, {28, 35}, {30, 35}, {32, 35}, {36, 36}, {36, 33}, {36, 30}, {37, 27},
+		{40, 26}, {41, 26}, {41, 24}, {40, 23}, {38, 24}, {37, 22}, {39, 20}, {42, 19},
+		{45, 14}, {45, 12}, {44, 12}, {42, 14}, {40, 18}, {38, 16}, {38, 15}, {41, 13},
+		{43, 10}, {44, 9}, {43, 7}, {43, 3}, {42, 3}, {41, 1}, {39, 0}, {37, -1},
+		{36, -5}, {37, -7},
+	},
+	// Afrique
+	{
+		{36, -6}, {35, -2}, {37, 10}, {33, 11}, {31, 19}, {33, 22}, {31, 29}, {31, 32},
+		{29, 33}, {27, 34}, {22, 37}, {18, 38}, {15, 40}, {12, 43}, {11, 44}, {12, 51},
+		{9, 51}, {4, 48}, {-2, 42}, {-6, 39}, {-11, 40}, {-16, 41}, {-20, 35}, {-25, 35},
+		{-29, 32}, {-34, 26}, {-35, 20}, {-34, 18}, {-29, 16}, {-23, 14}, {-17, 12},
+		{-12, 14}, {-6, 12}, {-1, 9}, {4, 9}, {4, 6}, {6, 2}, {5, -4}, {5, -8},
+		{7, -13}, {10, -15}, {15, -17}, {21, -17}, {26, -15}, {29, -10}, {33, -8},
+	},
+	// Madagascar
+	{{-12, 49}, {-16, 50}, {-25, 47}, {-25, 44}, {-20, 44}, {-16, 45}},
+	// Sri Lanka
+	{{9, 80}, {7, 82}, {6, 80}},
+	// Japon
+	{
+		{31, 130}, {34, 131}, {35, 133}, {36, 136}, {38, 139}, {41, 140}, {43, 141},
+		{45, 142}, {43, 145}, {42, 143}, {40, 142}, {37, 141}, {35, 140}, {34, 136},
+		{33, 132},
+	},
+	// Philippines
+	{{18, 121}, {14, 124}, {10, 126}, {7, 126}, {9, 122}, {14, 120}},
+	// Sumatra
+	{{5, 95}, {3, 98}, {-1, 104}, {-6, 106}, {-4, 102}, {0, 99}, {3, 96}},
+	// Java
+	{{-6, 106}, {-7, 112}, {-8, 114}, {-8, 109}, {-7, 106}},
+	// Bornéo
+	{{7, 117}, {1, 119}, {-4, 116}, {-3, 111}, {1, 109}, {4, 114}},
+	// Nouvelle-Guinée
+	{{-1, 131}, {-2, 141}, {-6, 148}, {-10, 150}, {-9, 143}, {-5, 138}, {-4, 133}},
+	// Australie
+	{
+		{-11, 142}, {-17, 141}, {-12, 136}, {-12, 131}, {-15, 129}, {-14, 126}, {-17, 122},
+		{-20, 119}, {-22, 114}, {-26, 113}, {-32, 115}, {-35, 117}, {-34, 123}, {-32, 127},
+		{-32, 132}, {-35, 136}, {-35, 138}, {-38, 140}, {-39, 146}, {-37, 150}, {-33, 152},
+		{-28, 153}, {-25, 153}, {-19, 147}, {-15, 145},
+	},
+	// Tasmanie
+	{{-41, 145}, {-41, 148}, {-43, 148}, {-43, 146}},
+	// Nouvelle-Zélande
+	{{-34, 173}, {-37, 176}, {-39, 178}, {-41, 175}, {-39, 174}, {-37, 174}},
+	{{-41, 174}, {-44, 173}, {-46, 170}, {-46, 167}, {-44, 168}, {-41, 172}},
+}
@@ -0,0 +1,102 @@
+package graphql
+
+// Document est une requête analysée : ses opérations et ses fragments nommés
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation est une opération (query, mutation, subscription)
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDef
+	SelectionSet []Selection
+	Line, Column int
+}
+
+// VariableDef déclare une variable d'opération : $name: Type = défaut
+type VariableDef struct {
+	Name    string
+	Type    string
+	Default interface{}
+}
+
+// Fragment est un fragment nommé : fragment Name on Type { ... }
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Line, Column  int
+}
+
+// Selection est un *Field, un *FragmentSpread ou un *InlineFragment
+type Selection interface {
+	selection()
+}
+
+// Field est un champ sélectionné, avec son alias et ses arguments
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Line, Column int
+}
+
+// ResponseKey est la clé du champ dans la réponse : l'alias s'il existe
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread est une référence à un fragment nommé : ...Name
+type FragmentSpread struct {
+	Name         string
+	Directives   []*Directive
+	Line, Column int
+}
+
+// InlineFragment est un fragment anonyme : ... on Type { ... }
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (*Field) selection()          {}
+func (*FragmentSpread) selection() {}
+func (*InlineFragment) selection() {}
+
+// Argument est un argument de champ ou de directive
+type Argument struct {
+	Name  string
+	Value interface{}
+}
+
+// Directive est une directive (@include, @skip)
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// Variable est une référence à une variable dans une valeur : $name
+type Variable struct {
+	Name string
+}
+
+// EnumValue est une valeur d'énumération littérale
+type EnumValue string
+
+// ObjectField est un champ d'objet littéral, dans l'ordre de la requête
+type ObjectField struct {
+	Name  string
+	Value interface{}
+}
+
+// ObjectValue est un objet littéral : { name: value }
+type ObjectValue []ObjectField
@@ -0,0 +1,270 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Execute analyse, valide puis exécute une requête contre le schéma.
+// Seules les opérations query sont prises en charge : le jeu de données est
+// en lecture seule.
+func (s *Schema) Execute(ctx context.Context, req Request) *Result {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Result{Errors: []*Error{asError(err)}}
+	}
+
+	op, gqlErr := selectOperation(doc, req.OperationName)
+	if gqlErr != nil {
+		return &Result{Errors: []*Error{gqlErr}}
+	}
+	if op.Type != "query" {
+		return &Result{Errors: []*Error{errorAt(op.Line, op.Column, "Only query operations are supported, got %q.", op.Type)}}
+	}
+
+	if errs := validate(s, doc, op); len(errs) > 0 {
+		return &Result{Errors: errs}
+	}
+
+	vars, errs := coerceVariables(op, req.Variables)
+	if len(errs) > 0 {
+		return &Result{Errors: errs}
+	}
+
+	e := &executor{schema: s, doc: doc, ctx: ctx, vars: vars}
+	data := e.selectionSet(s.Query, nil, op.SelectionSet, nil)
+	return &Result{Data: data, Errors: e.errs}
+}
+
+func asError(err error) *Error {
+	if gqlErr, ok := err.(*Error); ok {
+		return gqlErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, *Error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations."}
+		}
+		return doc.Operations[0], nil
+	}
+
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q.", name)}
+}
+
+func coerceVariables(op *Operation, provided map[string]interface{}) (map[string]interface{}, []*Error) {
+	vars := make(map[string]interface{})
+	var errs []*Error
+
+	for _, def := range op.Variables {
+		t, _ := parseTypeRef(def.Type)
+		value, ok := provided[def.Name]
+		if !ok {
+			if def.Default == nil {
+				if t.nonNull {
+					errs = append(errs, errorAt(op.Line, op.Column, "Variable \"$%s\" of required type %q was not provided.", def.Name, def.Type))
+				}
+				continue
+			}
+			value = def.Default
+		}
+
+		coerced, err := coerceInput(t, value)
+		if err != nil {
+			errs = append(errs, errorAt(op.Line, op.Column, "Variable \"$%s\" got invalid value: %v.", def.Name, err))
+			continue
+		}
+		vars[def.Name] = coerced
+	}
+	return vars, errs
+}
+
+type executor struct {
+	schema *Schema
+	doc    *Document
+	ctx    context.Context
+	vars   map[string]interface{}
+	errs   []*Error
+
+	fields  int
+	aborted bool
+}
+
+func (e *executor) fieldError(path []interface{}, f *Field, format string, args ...interface{}) {
+	err := errorAt(f.Line, f.Column, format, args...)
+	err.Path = path
+	e.errs = append(e.errs, err)
+}
+
+// selectionSet résout chaque champ sélectionné sur source, dans l'ordre de
+// la requête
+func (e *executor) selectionSet(obj *Object, source interface{}, set []Selection, path []interface{}) *OrderedMap {
+	keys, grouped := e.collectFields(obj, set, make(map[string]bool), nil, make(map[string][]*Field))
+	result := newOrderedMap()
+
+	for _, key := range keys {
+		fields := grouped[key]
+		fieldPath := append(path[:len(path):len(path)], key)
+		result.Set(key, e.resolveField(obj, source, fields, fieldPath))
+	}
+	return result
+}
+
+// collectFields aplatit fragments et directives en une liste de champs
+// groupés par clé de réponse (spec §6.3.2). Un fragment n'est développé
+// qu'une fois par sélection.
+func (e *executor) collectFields(obj *Object, set []Selection, visited map[string]bool, keys []string, grouped map[string][]*Field) ([]string, map[string][]*Field) {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *Field:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			key := sel.ResponseKey()
+			if _, exists := grouped[key]; !exists {
+				keys = append(keys, key)
+			}
+			grouped[key] = append(grouped[key], sel)
+
+		case *FragmentSpread:
+			if visited[sel.Name] || !e.included(sel.Directives) {
+				continue
+			}
+			visited[sel.Name] = true
+			frag := e.doc.Fragments[sel.Name]
+			if frag.TypeCondition != obj.Name || !e.included(frag.Directives) {
+				continue
+			}
+			keys, grouped = e.collectFields(obj, frag.SelectionSet, visited, keys, grouped)
+
+		case *InlineFragment:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			if sel.TypeCondition != "" && sel.TypeCondition != obj.Name {
+				continue
+			}
+			keys, grouped = e.collectFields(obj, sel.SelectionSet, visited, keys, grouped)
+		}
+	}
+	return keys, grouped
+}
+
+// included applique @skip(if:) et @include(if:)
+func (e *executor) included(dirs []*Directive) bool {
+	for _, dir := range dirs {
+		for _, arg := range dir.Arguments {
+			if arg.Name != "if" {
+				continue
+			}
+			cond, _ := substitute(arg.Value, e.vars).(bool)
+			if dir.Name == "skip" && cond {
+				return false
+			}
+			if dir.Name == "include" && !cond {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e *executor) resolveField(obj *Object, source interface{}, fields []*Field, path []interface{}) interface{} {
+	f := fields[0]
+	if e.aborted {
+		return nil
+	}
+
+	e.fields++
+	if e.schema.MaxFields > 0 && e.fields > e.schema.MaxFields {
+		e.aborted = true
+		e.fieldError(path, f, "Query result exceeds the maximum of %d fields.", e.schema.MaxFields)
+		return nil
+	}
+	if err := e.ctx.Err(); err != nil {
+		e.aborted = true
+		e.fieldError(path, f, "Query aborted: %v.", err)
+		return nil
+	}
+
+	if f.Name == "__typename" {
+		return obj.Name
+	}
+
+	def := obj.Fields[f.Name]
+	args := make(map[string]interface{})
+	for _, arg := range f.Arguments {
+		value, err := coerceInput(def.args[arg.Name], substitute(arg.Value, e.vars))
+		if err != nil {
+			e.fieldError(path, f, "Argument %q has invalid value: %v.", arg.Name, err)
+			return nil
+		}
+		if value != nil {
+			args[arg.Name] = value
+		}
+	}
+
+	value, err := def.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args})
+	if err != nil {
+		e.fieldError(path, f, "%s", err.Error())
+		return nil
+	}
+
+	// Les sous-sélections des champs de même clé sont fusionnées
+	var set []Selection
+	for _, field := range fields {
+		set = append(set, field.SelectionSet...)
+	}
+	return e.complete(def.typ, value, f, set, path)
+}
+
+// complete convertit la valeur d'un résolveur selon le type déclaré :
+// listes parcourues élément par élément, objets résolus récursivement,
+// scalaires transmis tels quels
+func (e *executor) complete(t *typeRef, value interface{}, f *Field, set []Selection, path []interface{}) interface{} {
+	if isNil(value) {
+		if t.nonNull {
+			e.fieldError(path, f, "Cannot return null for non-nullable field %q.", f.Name)
+		}
+		return nil
+	}
+
+	if t.elem != nil {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.fieldError(path, f, "Expected a list for field %q.", f.Name)
+			return nil
+		}
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			itemPath := append(path[:len(path):len(path)], i)
+			list[i] = e.complete(t.elem, rv.Index(i).Interface(), f, set, itemPath)
+		}
+		return list
+	}
+
+	if obj, ok := e.schema.types[t.name]; ok {
+		return e.selectionSet(obj, value, set, path)
+	}
+	return value
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
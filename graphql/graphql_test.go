@@ -0,0 +1,181 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type testNode struct {
+	ID       int
+	Name     string
+	Children []int
+}
+
+var testNodes = map[int]testNode{
+	1: {1, "racine", []int{2, 3}},
+	2: {2, "gauche", []int{3}},
+	3: {3, "droite", nil},
+}
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	node := &Object{Name: "Node"}
+	node.Fields = map[string]*FieldDef{
+		"id": {Type: "Int!", Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(testNode).ID, nil
+		}},
+		"name": {Type: "String!", Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(testNode).Name, nil
+		}},
+		"children": {Type: "[Node!]!", Resolve: func(p ResolveParams) (interface{}, error) {
+			var children []testNode
+			for _, id := range p.Source.(testNode).Children {
+				children = append(children, testNodes[id])
+			}
+			return children, nil
+		}},
+	}
+
+	query := &Object{Name: "Query", Fields: map[string]*FieldDef{
+		"node": {Type: "Node", Args: map[string]string{"id": "Int!"}, Resolve: func(p ResolveParams) (interface{}, error) {
+			n, ok := testNodes[p.Args["id"].(int)]
+			if !ok {
+				return nil, nil
+			}
+			return n, nil
+		}},
+		"broken": {Type: "String!", Resolve: func(p ResolveParams) (interface{}, error) {
+			return nil, nil
+		}},
+	}}
+
+	schema, err := NewSchema(query, node)
+	if err != nil {
+		t.Fatalf("NewSchema: %v", err)
+	}
+	schema.MaxDepth = 4
+	return schema
+}
+
+func run(t *testing.T, s *Schema, req Request) (string, *Result) {
+	t.Helper()
+	result := s.Execute(context.Background(), req)
+	out, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return string(out), result
+}
+
+func TestExecute(t *testing.T) {
+	s := testSchema(t)
+
+	tests := []struct {
+		name     string
+		req      Request
+		expected string
+	}{
+		{
+			"alias et ordre des champs",
+			Request{Query: `{ b: node(id: 2) { name id } a: node(id: 1) { __typename id } }`},
+			`{"data":{"b":{"name":"gauche","id":2},"a":{"__typename":"Node","id":1}}}`,
+		},
+		{
+			"variables et listes",
+			Request{
+				Query:     `query Q($id: Int!) { node(id: $id) { children { name } } }`,
+				Variables: map[string]interface{}{"id": float64(1)},
+			},
+			`{"data":{"node":{"children":[{"name":"gauche"},{"name":"droite"}]}}}`,
+		},
+		{
+			"valeur par défaut",
+			Request{Query: `query ($id: Int = 3) { node(id: $id) { name } }`},
+			`{"data":{"node":{"name":"droite"}}}`,
+		},
+		{
+			"fragments et directives",
+			Request{
+				Query: `query ($skip: Boolean!) {
+					node(id: 2) { ...F ... on Node { id @skip(if: $skip) } }
+				}
+				fragment F on Node { name }`,
+				Variables: map[string]interface{}{"skip": true},
+			},
+			`{"data":{"node":{"name":"gauche"}}}`,
+		},
+		{
+			"objet absent",
+			Request{Query: `{ node(id: 42) { name } }`},
+			`{"data":{"node":null}}`,
+		},
+	}
+
+	for _, test := range tests {
+		got, _ := run(t, s, test.req)
+		if got != test.expected {
+			t.Errorf("%s:\n got      %s\n expected %s", test.name, got, test.expected)
+		}
+	}
+}
+
+func TestExecuteErrors(t *testing.T) {
+	s := testSchema(t)
+
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"syntaxe", `{ node(id: 1) { name }`, "Syntax Error"},
+		{"champ inconnu", `{ node(id: 1) { age } }`, `Cannot query field "age" on type "Node"`},
+		{"argument manquant", `{ node { name } }`, `argument "id" of type "Int!" is required`},
+		{"argument invalide", `{ node(id: "un") { name } }`, `Argument "id" has invalid value`},
+		{"sous-sélection manquante", `{ node(id: 1) }`, "must have a selection of subfields"},
+		{"variable non déclarée", `{ node(id: $id) { name } }`, `Variable "$id" is not defined`},
+		{"fragment cyclique", `{ node(id: 1) { ...A } } fragment A on Node { ...A }`, "within itself"},
+		{"profondeur", `{ node(id: 1) { children { children { children { name } } } } }`, "exceeds the maximum allowed depth of 4"},
+		{"profondeur par fragment", `{ node(id: 1) { ...A } } fragment A on Node { children { children { children { id } } } }`, "exceeds the maximum allowed depth"},
+		{"mutation", `mutation { node(id: 1) { name } }`, "Only query operations are supported"},
+	}
+
+	for _, test := range tests {
+		got, result := run(t, s, Request{Query: test.query})
+		if result.Data != nil {
+			t.Errorf("%s: data should be absent, got %s", test.name, got)
+		}
+		if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, test.message) {
+			t.Errorf("%s: expected error containing %q, got %s", test.name, test.message, got)
+		}
+	}
+}
+
+func TestExecuteFieldErrors(t *testing.T) {
+	s := testSchema(t)
+
+	got, result := run(t, s, Request{Query: `{ node(id: 1) { id } broken }`})
+	expected := `{"data":{"node":{"id":1},"broken":null},"errors":[{"message":"Cannot return null for non-nullable field \"broken\".","locations":[{"line":1,"column":22}],"path":["broken"]}]}`
+	if got != expected {
+		t.Errorf("got      %s\nexpected %s", got, expected)
+	}
+
+	s.MaxFields = 3
+	_, result = run(t, s, Request{Query: `{ node(id: 1) { children { id name } } }`})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "maximum of 3 fields") {
+		t.Errorf("expected field budget error, got %v", result.Errors)
+	}
+}
+
+func TestParseLocations(t *testing.T) {
+	_, err := Parse("{\n  node(id: 1) {\n    name ?\n  }\n}")
+	gqlErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Parse: expected *Error, got %v", err)
+	}
+	if len(gqlErr.Locations) != 1 || gqlErr.Locations[0] != (Location{Line: 3, Column: 10}) {
+		t.Errorf("Parse: locations = %v; expected [{3 10}]", gqlErr.Locations)
+	}
+}
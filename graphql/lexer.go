@@ -0,0 +1,233 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+	col   int
+}
+
+// lexer découpe une requête GraphQL en jetons (spec §2.1)
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1, col: 1}
+}
+
+func (l *lexer) errorf(line, col int, format string, args ...interface{}) error {
+	return &Error{
+		Message:   "Syntax Error: " + fmt.Sprintf(format, args...),
+		Locations: []Location{{Line: line, Column: col}},
+	}
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+// skipIgnored saute les espaces, virgules, BOM et commentaires
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	line, col := l.line, l.col
+
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, line: line, col: col}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+		l.advance(1)
+		return token{kind: tokPunct, value: string(c), line: line, col: col}, nil
+
+	case c == '.':
+		if strings.HasPrefix(l.src[l.pos:], "...") {
+			l.advance(3)
+			return token{kind: tokPunct, value: "...", line: line, col: col}, nil
+		}
+		return token{}, l.errorf(line, col, "unexpected \".\"")
+
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		return token{kind: tokName, value: l.src[start:l.pos], line: line, col: col}, nil
+
+	case c == '-' || isDigit(c):
+		return l.number(line, col)
+
+	case c == '"':
+		return l.string(line, col)
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return token{}, l.errorf(line, col, "unexpected character %q", r)
+}
+
+func (l *lexer) number(line, col int) (token, error) {
+	start := l.pos
+	kind := tokInt
+
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	if l.pos >= len(l.src) || !isDigit(l.src[l.pos]) {
+		return token{}, l.errorf(line, col, "invalid number")
+	}
+	if l.src[l.pos] == '0' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1]) {
+		return token{}, l.errorf(line, col, "invalid number, unexpected digit after 0")
+	}
+	l.digits()
+
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokFloat
+		l.advance(1)
+		if l.pos >= len(l.src) || !isDigit(l.src[l.pos]) {
+			return token{}, l.errorf(line, col, "invalid number, expected digit after \".\"")
+		}
+		l.digits()
+	}
+
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if l.pos >= len(l.src) || !isDigit(l.src[l.pos]) {
+			return token{}, l.errorf(line, col, "invalid number, expected digit in exponent")
+		}
+		l.digits()
+	}
+
+	return token{kind: kind, value: l.src[start:l.pos], line: line, col: col}, nil
+}
+
+func (l *lexer) digits() {
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.advance(1)
+	}
+}
+
+// string lit une chaîne simple avec ses séquences d'échappement.
+// Les chaînes en bloc ("""...""") sont lues sans traitement d'indentation.
+func (l *lexer) string(line, col int) (token, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		l.advance(3)
+		end := strings.Index(l.src[l.pos:], `"""`)
+		if end < 0 {
+			return token{}, l.errorf(line, col, "unterminated string")
+		}
+		value := l.src[l.pos : l.pos+end]
+		l.advance(end + 3)
+		return token{kind: tokString, value: value, line: line, col: col}, nil
+	}
+
+	l.advance(1)
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return token{}, l.errorf(line, col, "unterminated string")
+		}
+		c := l.src[l.pos]
+		if c == '"' {
+			l.advance(1)
+			return token{kind: tokString, value: sb.String(), line: line, col: col}, nil
+		}
+		if c != '\\' {
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			sb.WriteRune(r)
+			l.advance(size)
+			continue
+		}
+
+		if l.pos+1 >= len(l.src) {
+			return token{}, l.errorf(line, col, "unterminated string")
+		}
+		esc := l.src[l.pos+1]
+		l.advance(2)
+		switch esc {
+		case '"', '\\', '/':
+			sb.WriteByte(esc)
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'u':
+			if l.pos+4 > len(l.src) {
+				return token{}, l.errorf(line, col, "invalid unicode escape")
+			}
+			var r rune
+			if _, err := fmt.Sscanf(l.src[l.pos:l.pos+4], "%04x", &r); err != nil {
+				return token{}, l.errorf(line, col, "invalid unicode escape")
+			}
+			sb.WriteRune(r)
+			l.advance(4)
+		default:
+			return token{}, l.errorf(line, col, "invalid escape sequence \\%c", esc)
+		}
+	}
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,467 @@
+package graphql
+
+import (
+	"strconv"
+)
+
+// Parse analyse une requête GraphQL exécutable (opérations et fragments).
+// Les définitions de schéma (type, schema, extend...) sont refusées.
+func Parse(query string) (*Document, error) {
+	p := &parser{lex: newLexer(query)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.peek(tokPunct, "{"):
+			op := &Operation{Type: "query", Line: p.tok.line, Column: p.tok.col}
+			set, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			op.SelectionSet = set
+			doc.Operations = append(doc.Operations, op)
+
+		case p.peek(tokName, "query"), p.peek(tokName, "mutation"), p.peek(tokName, "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+
+		case p.peek(tokName, "fragment"):
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.Fragments[frag.Name]; exists {
+				return nil, errorAt(frag.Line, frag.Column, "There can be only one fragment named %q.", frag.Name)
+			}
+			doc.Fragments[frag.Name] = frag
+
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, &Error{Message: "Syntax Error: document contains no operation"}
+	}
+	return doc, nil
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return p.lex.errorf(p.tok.line, p.tok.col, "unexpected end of document")
+	}
+	return p.lex.errorf(p.tok.line, p.tok.col, "unexpected %q", p.tok.value)
+}
+
+// expect consomme le jeton de ponctuation attendu
+func (p *parser) expect(value string) error {
+	if !p.peek(tokPunct, value) {
+		if p.tok.kind == tokEOF {
+			return p.lex.errorf(p.tok.line, p.tok.col, "expected %q, found end of document", value)
+		}
+		return p.lex.errorf(p.tok.line, p.tok.col, "expected %q, found %q", value, p.tok.value)
+	}
+	return p.advance()
+}
+
+// skip consomme le jeton s'il correspond et indique s'il l'a fait
+func (p *parser) skip(value string) (bool, error) {
+	if !p.peek(tokPunct, value) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Type: p.tok.value, Line: p.tok.line, Column: p.tok.col}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokName {
+		op.Name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(tokPunct, ")") {
+			def, err := p.variableDef()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = append(op.Variables, def)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Les directives d'opération sont acceptées mais sans effet
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	set, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.SelectionSet = set
+	return op, nil
+}
+
+func (p *parser) variableDef() (*VariableDef, error) {
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	typ, err := p.typeRef()
+	if err != nil {
+		return nil, err
+	}
+
+	def := &VariableDef{Name: name, Type: typ}
+	if ok, err := p.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		if def.Default, err = p.value(true); err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
+// typeRef lit une référence de type et la restitue sous forme textuelle
+// ("Int", "[String!]!"), comme dans les déclarations du schéma
+func (p *parser) typeRef() (string, error) {
+	var typ string
+	if ok, err := p.skip("["); err != nil {
+		return "", err
+	} else if ok {
+		inner, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+
+	if ok, err := p.skip("!"); err != nil {
+		return "", err
+	} else if ok {
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	frag := &Fragment{Line: p.tok.line, Column: p.tok.col}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, errorAt(frag.Line, frag.Column, "Syntax Error: unexpected name \"on\"")
+	}
+	frag.Name = name
+
+	if !p.peek(tokName, "on") {
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if frag.TypeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if frag.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if frag.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var set []Selection
+	for !p.peek(tokPunct, "}") {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, sel)
+	}
+	if len(set) == 0 {
+		return nil, p.lex.errorf(p.tok.line, p.tok.col, "empty selection set")
+	}
+	return set, p.advance()
+}
+
+func (p *parser) selection() (Selection, error) {
+	if !p.peek(tokPunct, "...") {
+		return p.field()
+	}
+
+	line, col := p.tok.line, p.tok.col
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	// ...Name est une référence à un fragment nommé
+	if p.tok.kind == tokName && p.tok.value != "on" {
+		spread := &FragmentSpread{Name: p.tok.value, Line: line, Column: col}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		if spread.Directives, err = p.directives(); err != nil {
+			return nil, err
+		}
+		return spread, nil
+	}
+
+	inline := &InlineFragment{}
+	if p.peek(tokName, "on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		if inline.TypeCondition, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if inline.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if inline.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+func (p *parser) field() (*Field, error) {
+	f := &Field{Line: p.tok.line, Column: p.tok.col}
+
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		f.Alias = name
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	f.Name = name
+
+	if f.Arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokPunct, "{") {
+		if f.SelectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) arguments(constant bool) ([]*Argument, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+
+	var args []*Argument
+	for !p.peek(tokPunct, ")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.value(constant)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, &Argument{Name: name, Value: value})
+	}
+	if len(args) == 0 {
+		return nil, p.lex.errorf(p.tok.line, p.tok.col, "empty argument list")
+	}
+	return args, p.advance()
+}
+
+func (p *parser) directives() ([]*Directive, error) {
+	var dirs []*Directive
+	for p.peek(tokPunct, "@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments(false)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, &Directive{Name: name, Arguments: args})
+	}
+	return dirs, nil
+}
+
+// value lit une valeur littérale. En contexte constant (valeur par défaut
+// d'une variable), les références à d'autres variables sont interdites.
+func (p *parser) value(constant bool) (interface{}, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				return nil, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			return Variable{Name: name}, nil
+
+		case "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			list := []interface{}{}
+			for !p.peek(tokPunct, "]") {
+				item, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			return list, p.advance()
+
+		case "{":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			obj := ObjectValue{}
+			for !p.peek(tokPunct, "}") {
+				name, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				item, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, ObjectField{Name: name, Value: item})
+			}
+			return obj, p.advance()
+		}
+
+	case tokInt:
+		n, err := strconv.Atoi(tok.value)
+		if err != nil {
+			return nil, p.lex.errorf(tok.line, tok.col, "integer %s out of range", tok.value)
+		}
+		return n, p.advance()
+
+	case tokFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, p.lex.errorf(tok.line, tok.col, "invalid float %s", tok.value)
+		}
+		return f, p.advance()
+
+	case tokString:
+		return tok.value, p.advance()
+
+	case tokName:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		switch tok.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return EnumValue(tok.value), nil
+	}
+
+	return nil, p.unexpected()
+}
@@ -0,0 +1,101 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Request est le corps d'une requête GraphQL sur HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Result est la réponse : data est absent si la requête n'a pas pu être
+// exécutée (erreur de syntaxe ou de validation)
+type Result struct {
+	Data   *OrderedMap `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error est une erreur GraphQL, avec sa position dans la requête ou son
+// chemin dans la réponse
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Location est une position (ligne, colonne) dans la requête
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func errorAt(line, col int, format string, args ...interface{}) *Error {
+	err := &Error{Message: fmt.Sprintf(format, args...)}
+	if line > 0 {
+		err.Locations = []Location{{Line: line, Column: col}}
+	}
+	return err
+}
+
+// OrderedMap conserve l'ordre des champs demandé par la requête, que
+// encoding/json ne garantit pas pour une map
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *OrderedMap {
+	return &OrderedMap{values: make(map[string]interface{})}
+}
+
+// Set ajoute ou remplace une clé
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get retourne la valeur associée à une clé
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Keys retourne les clés dans l'ordre d'insertion
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,150 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Scalaires intégrés (spec §3.5)
+var builtinScalars = map[string]bool{
+	"Int":     true,
+	"Float":   true,
+	"String":  true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// ResolveParams est transmis à chaque résolveur
+type ResolveParams struct {
+	Context context.Context
+	// Source est la valeur résolue par le champ parent (nil à la racine)
+	Source interface{}
+	// Args contient les arguments fournis, déjà convertis selon leur type
+	Args map[string]interface{}
+}
+
+// ResolveFunc calcule la valeur d'un champ
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+// Object est un type objet du schéma
+type Object struct {
+	Name   string
+	Fields map[string]*FieldDef
+}
+
+// FieldDef décrit un champ : son type ("Int", "[Artist!]!"), ses arguments
+// (nom ➡️ type scalaire) et son résolveur
+type FieldDef struct {
+	Type    string
+	Args    map[string]string
+	Resolve ResolveFunc
+
+	typ  *typeRef
+	args map[string]*typeRef
+}
+
+// Schema regroupe le type Query et les types objets qu'il référence
+type Schema struct {
+	Query *Object
+	// MaxDepth limite la profondeur d'imbrication des sélections (0 = illimitée)
+	MaxDepth int
+	// MaxFields limite le nombre de champs résolus par requête (0 = illimité)
+	MaxFields int
+
+	types map[string]*Object
+}
+
+// NewSchema vérifie que chaque champ référence un type connu et que chaque
+// argument est d'un type scalaire
+func NewSchema(query *Object, types ...*Object) (*Schema, error) {
+	s := &Schema{Query: query, types: map[string]*Object{query.Name: query}}
+	for _, obj := range types {
+		if _, exists := s.types[obj.Name]; exists || builtinScalars[obj.Name] {
+			return nil, fmt.Errorf("graphql: type %s défini plusieurs fois", obj.Name)
+		}
+		s.types[obj.Name] = obj
+	}
+
+	for _, obj := range s.types {
+		for name, field := range obj.Fields {
+			if strings.HasPrefix(name, "__") {
+				return nil, fmt.Errorf("graphql: le champ %s.%s est réservé", obj.Name, name)
+			}
+			if field.Resolve == nil {
+				return nil, fmt.Errorf("graphql: le champ %s.%s n'a pas de résolveur", obj.Name, name)
+			}
+
+			t, err := parseTypeRef(field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("graphql: %s.%s: %v", obj.Name, name, err)
+			}
+			if !builtinScalars[t.namedType()] && s.types[t.namedType()] == nil {
+				return nil, fmt.Errorf("graphql: %s.%s: type inconnu %s", obj.Name, name, t.namedType())
+			}
+			field.typ = t
+
+			field.args = make(map[string]*typeRef)
+			for argName, argType := range field.Args {
+				at, err := parseTypeRef(argType)
+				if err != nil {
+					return nil, fmt.Errorf("graphql: %s.%s(%s): %v", obj.Name, name, argName, err)
+				}
+				if !builtinScalars[at.namedType()] {
+					return nil, fmt.Errorf("graphql: %s.%s(%s): les arguments doivent être scalaires", obj.Name, name, argName)
+				}
+				field.args[argName] = at
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// typeRef est la forme analysée d'une référence de type
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+func parseTypeRef(s string) (*typeRef, error) {
+	t := &typeRef{}
+	if strings.HasSuffix(s, "!") {
+		t.nonNull = true
+		s = strings.TrimSuffix(s, "!")
+	}
+
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		elem, err := parseTypeRef(s[1 : len(s)-1])
+		if err != nil {
+			return nil, err
+		}
+		t.elem = elem
+		return t, nil
+	}
+
+	if s == "" || strings.ContainsAny(s, "[]! ") {
+		return nil, fmt.Errorf("type invalide %q", s)
+	}
+	t.name = s
+	return t, nil
+}
+
+func (t *typeRef) namedType() string {
+	for t.elem != nil {
+		t = t.elem
+	}
+	return t.name
+}
+
+func (t *typeRef) String() string {
+	s := t.name
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
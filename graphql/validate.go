@@ -0,0 +1,207 @@
+package graphql
+
+// validator vérifie une opération contre le schéma avant exécution :
+// champs et arguments connus, sous-sélections cohérentes, fragments
+// applicables et sans cycle, variables déclarées, profondeur maximale
+type validator struct {
+	schema *Schema
+	doc    *Document
+	vars   map[string]*VariableDef
+	errs   []*Error
+
+	// Profondeur relative de chaque fragment déjà vérifié, pour ne pas
+	// réexaminer un fragment réutilisé (et désamorcer les fragments imbriqués
+	// en cascade)
+	fragDepth map[string]int
+	visiting  map[string]bool
+}
+
+func validate(schema *Schema, doc *Document, op *Operation) []*Error {
+	v := &validator{
+		schema:    schema,
+		doc:       doc,
+		vars:      make(map[string]*VariableDef),
+		fragDepth: make(map[string]int),
+		visiting:  make(map[string]bool),
+	}
+
+	for _, def := range op.Variables {
+		if _, exists := v.vars[def.Name]; exists {
+			v.errorf(op.Line, op.Column, "There can be only one variable named \"$%s\".", def.Name)
+			continue
+		}
+		t, err := parseTypeRef(def.Type)
+		if err != nil || !builtinScalars[t.namedType()] {
+			v.errorf(op.Line, op.Column, "Variable \"$%s\" cannot be non-input type %q.", def.Name, def.Type)
+			continue
+		}
+		if def.Default != nil {
+			if _, err := coerceInput(t, def.Default); err != nil {
+				v.errorf(op.Line, op.Column, "Variable \"$%s\" has invalid default value: %v.", def.Name, err)
+			}
+		}
+		v.vars[def.Name] = def
+	}
+
+	depth := v.selectionSet(schema.Query, op.SelectionSet)
+	if schema.MaxDepth > 0 && depth > schema.MaxDepth {
+		v.errorf(op.Line, op.Column, "Query depth %d exceeds the maximum allowed depth of %d.", depth, schema.MaxDepth)
+	}
+
+	return v.errs
+}
+
+func (v *validator) errorf(line, col int, format string, args ...interface{}) {
+	v.errs = append(v.errs, errorAt(line, col, format, args...))
+}
+
+// selectionSet vérifie une sélection et retourne sa profondeur : 1 pour une
+// sélection de champs scalaires, plus un par niveau d'objet imbriqué
+func (v *validator) selectionSet(obj *Object, set []Selection) int {
+	max := 0
+	for _, sel := range set {
+		depth := 0
+		switch sel := sel.(type) {
+		case *Field:
+			depth = v.field(obj, sel)
+		case *FragmentSpread:
+			v.directives(sel.Directives, sel.Line, sel.Column)
+			depth = v.fragmentSpread(obj, sel)
+		case *InlineFragment:
+			v.directives(sel.Directives, 0, 0)
+			if sel.TypeCondition != "" && !v.applies(obj, sel.TypeCondition, "", 0, 0) {
+				continue
+			}
+			depth = v.selectionSet(obj, sel.SelectionSet)
+		}
+		if depth > max {
+			max = depth
+		}
+	}
+	return max
+}
+
+func (v *validator) field(obj *Object, f *Field) int {
+	v.directives(f.Directives, f.Line, f.Column)
+
+	if f.Name == "__typename" {
+		if len(f.Arguments) > 0 || f.SelectionSet != nil {
+			v.errorf(f.Line, f.Column, "Field \"__typename\" takes no arguments and has no subfields.")
+		}
+		return 1
+	}
+
+	def, ok := obj.Fields[f.Name]
+	if !ok {
+		v.errorf(f.Line, f.Column, "Cannot query field %q on type %q.", f.Name, obj.Name)
+		return 1
+	}
+
+	v.arguments(def.args, f.Arguments, f.Name, f.Line, f.Column)
+
+	child, isObject := v.schema.types[def.typ.namedType()]
+	switch {
+	case isObject && f.SelectionSet == nil:
+		v.errorf(f.Line, f.Column, "Field %q of type %q must have a selection of subfields.", f.Name, def.Type)
+		return 1
+	case !isObject && f.SelectionSet != nil:
+		v.errorf(f.Line, f.Column, "Field %q must not have a selection since type %q has no subfields.", f.Name, def.Type)
+		return 1
+	case !isObject:
+		return 1
+	}
+
+	return 1 + v.selectionSet(child, f.SelectionSet)
+}
+
+func (v *validator) fragmentSpread(obj *Object, spread *FragmentSpread) int {
+	frag, ok := v.doc.Fragments[spread.Name]
+	if !ok {
+		v.errorf(spread.Line, spread.Column, "Unknown fragment %q.", spread.Name)
+		return 0
+	}
+	if !v.applies(obj, frag.TypeCondition, frag.Name, spread.Line, spread.Column) {
+		return 0
+	}
+
+	if v.visiting[frag.Name] {
+		v.errorf(spread.Line, spread.Column, "Cannot spread fragment %q within itself.", frag.Name)
+		return 0
+	}
+	if depth, done := v.fragDepth[frag.Name]; done {
+		return depth
+	}
+
+	v.visiting[frag.Name] = true
+	v.directives(frag.Directives, frag.Line, frag.Column)
+	depth := v.selectionSet(v.schema.types[frag.TypeCondition], frag.SelectionSet)
+	delete(v.visiting, frag.Name)
+
+	v.fragDepth[frag.Name] = depth
+	return depth
+}
+
+// applies vérifie qu'un fragment de condition typeName peut s'appliquer à
+// obj. Le schéma n'a que des types objets : la condition doit être exacte.
+func (v *validator) applies(obj *Object, typeName, fragName string, line, col int) bool {
+	if _, known := v.schema.types[typeName]; !known {
+		v.errorf(line, col, "Unknown type %q.", typeName)
+		return false
+	}
+	if typeName != obj.Name {
+		if fragName != "" {
+			v.errorf(line, col, "Fragment %q cannot be spread here as objects of type %q can never be of type %q.", fragName, obj.Name, typeName)
+		} else {
+			v.errorf(line, col, "Fragment cannot be spread here as objects of type %q can never be of type %q.", obj.Name, typeName)
+		}
+		return false
+	}
+	return true
+}
+
+func (v *validator) arguments(defs map[string]*typeRef, args []*Argument, fieldName string, line, col int) {
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		if seen[arg.Name] {
+			v.errorf(line, col, "There can be only one argument named %q.", arg.Name)
+			continue
+		}
+		seen[arg.Name] = true
+
+		t, ok := defs[arg.Name]
+		if !ok {
+			v.errorf(line, col, "Unknown argument %q on field %q.", arg.Name, fieldName)
+			continue
+		}
+		v.variables(arg.Value, line, col)
+		if !hasVariable(arg.Value) {
+			if _, err := coerceInput(t, arg.Value); err != nil {
+				v.errorf(line, col, "Argument %q has invalid value: %v.", arg.Name, err)
+			}
+		}
+	}
+
+	for name, t := range defs {
+		if t.nonNull && !seen[name] {
+			v.errorf(line, col, "Field %q argument %q of type %q is required, but it was not provided.", fieldName, name, t)
+		}
+	}
+}
+
+func (v *validator) directives(dirs []*Directive, line, col int) {
+	for _, dir := range dirs {
+		if dir.Name != "include" && dir.Name != "skip" {
+			v.errorf(line, col, "Unknown directive \"@%s\".", dir.Name)
+			continue
+		}
+		v.arguments(map[string]*typeRef{"if": {name: "Boolean", nonNull: true}}, dir.Arguments, "@"+dir.Name, line, col)
+	}
+}
+
+func (v *validator) variables(value interface{}, line, col int) {
+	for _, name := range variableNames(value) {
+		if _, ok := v.vars[name]; !ok {
+			v.errorf(line, col, "Variable \"$%s\" is not defined.", name)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// substitute remplace les références de variables par leur valeur
+func substitute(value interface{}, vars map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case Variable:
+		return vars[v.Name]
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = substitute(item, vars)
+		}
+		return out
+	case ObjectValue:
+		out := make(ObjectValue, len(v))
+		for i, field := range v {
+			out[i] = ObjectField{Name: field.Name, Value: substitute(field.Value, vars)}
+		}
+		return out
+	}
+	return value
+}
+
+// hasVariable indique si une valeur littérale contient une variable
+func hasVariable(value interface{}) bool {
+	switch v := value.(type) {
+	case Variable:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if hasVariable(item) {
+				return true
+			}
+		}
+	case ObjectValue:
+		for _, field := range v {
+			if hasVariable(field.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// variableNames retourne les variables référencées par une valeur
+func variableNames(value interface{}) []string {
+	switch v := value.(type) {
+	case Variable:
+		return []string{v.Name}
+	case []interface{}:
+		var names []string
+		for _, item := range v {
+			names = append(names, variableNames(item)...)
+		}
+		return names
+	case ObjectValue:
+		var names []string
+		for _, field := range v {
+			names = append(names, variableNames(field.Value)...)
+		}
+		return names
+	}
+	return nil
+}
+
+// coerceInput convertit une valeur d'entrée (littéral ou variable JSON)
+// vers le type scalaire attendu (spec §3.5 et §3.11)
+func coerceInput(t *typeRef, value interface{}) (interface{}, error) {
+	if value == nil {
+		if t.nonNull {
+			return nil, fmt.Errorf("expected non-null value of type %s", t)
+		}
+		return nil, nil
+	}
+
+	if t.elem != nil {
+		items, ok := value.([]interface{})
+		if !ok {
+			// Une valeur unique est acceptée comme une liste d'un élément
+			items = []interface{}{value}
+		}
+		out := make([]interface{}, len(items))
+		for i, item := range items {
+			v, err := coerceInput(t.elem, item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = v
+		}
+		return out, nil
+	}
+
+	switch t.name {
+	case "Int":
+		switch v := value.(type) {
+		case int:
+			if v >= math.MinInt32 && v <= math.MaxInt32 {
+				return v, nil
+			}
+		case float64:
+			// Les nombres des variables JSON arrivent en float64
+			if v == math.Trunc(v) && v >= math.MinInt32 && v <= math.MaxInt32 {
+				return int(v), nil
+			}
+		}
+	case "Float":
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case "String":
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case "Boolean":
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case "ID":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case int:
+			return strconv.Itoa(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return strconv.FormatFloat(v, 'f', -1, 64), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("expected type %s, found %s", t, describe(value))
+}
+
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case EnumValue:
+		return string(v)
+	case ObjectValue, map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "a list"
+	}
+	return fmt.Sprint(value)
+}
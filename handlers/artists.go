@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/geo"
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/models"
+)
+
+// Clé de l'API Google Maps. Vide, la page artiste n'affiche que la carte
+// SVG rendue par le serveur.
+var googleMapsKey string
+
+// SetGoogleMapsKey fixe la clé utilisée pour la carte interactive
+func SetGoogleMapsKey(key string) {
+	googleMapsKey = key
+}
+
+// OverlapsResponse est la réponse de /api/v1/artists/{id}/overlaps
+type OverlapsResponse struct {
+	ArtistID   int              `json:"artistId"`
+	ArtistName string           `json:"artistName"`
+	WindowDays int              `json:"windowDays"`
+	Overlaps   []models.Overlap `json:"overlaps"`
+}
+
+// SimilarResponse est la réponse de /api/v1/artists/{id}/similar
+type SimilarResponse struct {
+	ArtistID   int                    `json:"artistId"`
+	ArtistName string                 `json:"artistName"`
+	Similar    []models.SimilarArtist `json:"similar"`
+}
+
+// MapResponse est la réponse de /api/v1/artists/{id}/map, également
+// embarquée dans la page artiste pour la carte
+type MapResponse struct {
+	ArtistID   int               `json:"artistId"`
+	ArtistName string            `json:"artistName"`
+	Places     []models.MapPlace `json:"places"`
+}
+
+func newMapResponse(artist models.Artist) MapResponse {
+	return MapResponse{
+		ArtistID:   artist.ID,
+		ArtistName: artist.Name,
+		Places:     api.GetArtistPlaces(artist.ID),
+	}
+}
+
+// artistMapJSON sérialise les lieux de la carte pour le bloc
+// <script type="application/json"> de la page artiste. json.Marshal échappe
+// <, >, &, U+2028 et U+2029 : un nom de lieu ne peut pas refermer la balise.
+func artistMapJSON(data MapResponse) template.JS {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return "null"
+	}
+	return template.JS(content)
+}
+
+// artistStaticMap dessine la carte SVG des concerts, affichée sans
+// JavaScript ni clé Google Maps. Chaque marqueur mène à la page du lieu et
+// liste ses dates en infobulle ; les lieux sans coordonnées sont ignorés.
+func artistStaticMap(lang string, data MapResponse) template.HTML {
+	markers := make([]geo.Marker, 0, len(data.Places))
+	for _, place := range data.Places {
+		if !place.Located {
+			continue
+		}
+		dates := make([]string, 0, len(place.Dates))
+		for _, date := range place.Dates {
+			dates = append(dates, i18n.FormatDate(lang, date.Time))
+		}
+		markers = append(markers, geo.Marker{
+			Point:       geo.Point{Lat: place.Lat, Lon: place.Lon},
+			Title:       i18n.T(lang, "artist.mapMarker", place.Name, strings.Join(dates, ", ")),
+			Href:        "/location/" + url.PathEscape(place.Location),
+			Approximate: place.Approximate,
+		})
+	}
+	// WorldMapSVG échappe titres et liens
+	return template.HTML(geo.WorldMapSVG(i18n.T(lang, "artist.mapTitle", data.ArtistName), markers))
+}
+
+// localizeSimilar traduit les raisons des recommandations dans la langue
+// lang. La liste partagée par api n'est pas modifiée : elle est copiée.
+func localizeSimilar(lang string, list []models.SimilarArtist) []models.SimilarArtist {
+	localized := make([]models.SimilarArtist, len(list))
+	for i, artist := range list {
+		reasons := make([]models.SimilarReason, len(artist.Reasons))
+		for j, reason := range artist.Reasons {
+			reason.Text = similarReasonText(lang, reason)
+			reasons[j] = reason
+		}
+		artist.Reasons = reasons
+		localized[i] = artist
+	}
+	return localized
+}
+
+// similarReasonText traduit une raison : l'année de création, la même
+// époque (sans valeur) ou un nombre accordé (membres, pays, concerts)
+func similarReasonText(lang string, reason models.SimilarReason) string {
+	switch reason.Key {
+	case "similar.created":
+		return i18n.T(lang, reason.Key, reason.Value)
+	case "similar.sameEra":
+		return i18n.T(lang, reason.Key)
+	}
+	return i18n.N(lang, reason.Key, reason.Value)
+}
+
+// =======================
+// API ARTISTS
+// =======================
+
+// ArtistAPIHandler sert /api/v1/artists/{id}/{ressource}
+func ArtistAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/artists/"), "/"), "/")
+	if len(parts) != 2 {
+		writeError(w, r, newError(http.StatusNotFound, "error.unknownResource"))
+		return
+	}
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		writeError(w, r, newError(http.StatusBadRequest, "error.invalidArtistID"))
+		return
+	}
+
+	artist, err := api.GetArtistByID(id)
+	if err != nil {
+		writeError(w, r, newError(http.StatusNotFound, "error.artistNotFound"))
+		return
+	}
+
+	switch parts[1] {
+	case "overlaps":
+		writeJSON(w, http.StatusOK, OverlapsResponse{
+			ArtistID:   artist.ID,
+			ArtistName: artist.Name,
+			WindowDays: api.OverlapWindowDays,
+			Overlaps:   api.GetOverlaps(id),
+		})
+	case "map":
+		writeJSON(w, http.StatusOK, newMapResponse(*artist))
+	case "similar":
+		writeJSON(w, http.StatusOK, SimilarResponse{
+			ArtistID:   artist.ID,
+			ArtistName: artist.Name,
+			Similar:    localizeSimilar(requestLang(r), api.GetSimilar(id)),
+		})
+	default:
+		writeError(w, r, newError(http.StatusNotFound, "error.unknownResource"))
+	}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/models"
+)
+
+// Noms de lieux tentant de sortir du bloc JSON de la carte
+var hostileLocations = []string{
+	`</script><script>alert(1)</script>-uk`,
+	`"};alert(1);//-uk`,
+	"<!--\u2028-->-uk",
+	`london-uk`,
+}
+
+// loadArtistLocations charge un artiste (Queen, id 1) jouant aux locations
+// données, et les vrais templates
+func loadArtistLocations(t *testing.T, locations []string) {
+	t.Helper()
+	loadArtist(t, map[string]interface{}{"id": 1, "name": "Queen", "firstAlbum": "14-12-1973"}, locations)
+}
+
+// loadArtist charge un unique artiste, qui donne un concert le 01-09-2019
+// à chaque location, et les vrais templates
+func loadArtist(t *testing.T, artist map[string]interface{}, locations []string) {
+	t.Helper()
+	dates := make(map[string][]string)
+	for _, location := range locations {
+		dates[location] = []string{"01-09-2019"}
+	}
+	content, err := json.Marshal(map[string]interface{}{
+		"savedAt":   time.Now().UTC(),
+		"artists":   []map[string]interface{}{artist},
+		"relations": map[string]interface{}{"index": []map[string]interface{}{{"id": 1, "datesLocations": dates}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := filepath.Join(t.TempDir(), "data.json")
+	if err := os.WriteFile(snapshot, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.LoadSnapshot(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if err := UseTemplates(os.DirFS("../templates"), false); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { templates = nil })
+}
+
+func TestArtistMapEmbedding(t *testing.T) {
+	loadArtistLocations(t, hostileLocations)
+
+	rec := httptest.NewRecorder()
+	ArtistHandler(rec, httptest.NewRequest("GET", "/artist/1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /artist/1 = %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, "<script>alert(1)") {
+		t.Error("a location name was written unescaped in the page")
+	}
+
+	// Le bloc JSON se termine à la première balise fermante et redonne les
+	// noms de lieux intacts
+	const open = `<script type="application/json" id="artist-map">`
+	start := strings.Index(body, open)
+	if start < 0 {
+		t.Fatal("map data block not found")
+	}
+	block, _, _ := strings.Cut(body[start+len(open):], "</script>")
+	if strings.ContainsAny(block, "<>\u2028") {
+		t.Errorf("map data block contains unescaped characters: %s", block)
+	}
+
+	var embedded MapResponse
+	if err := json.Unmarshal([]byte(block), &embedded); err != nil {
+		t.Fatalf("map data block is not valid JSON: %v\n%s", err, block)
+	}
+	if len(embedded.Places) != len(hostileLocations) {
+		t.Fatalf("places = %+v; expected %d", embedded.Places, len(hostileLocations))
+	}
+	found := make(map[string]bool)
+	for _, place := range embedded.Places {
+		found[place.Location] = true
+		if len(place.Dates) != 1 || !place.Dates[0].Equal(time.Date(2019, time.September, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("%q: dates = %v", place.Location, place.Dates)
+		}
+	}
+	for _, location := range hostileLocations {
+		if !found[location] {
+			t.Errorf("location %q missing from the map data", location)
+		}
+	}
+
+	// La carte SVG est rendue sans clé Google Maps, un marqueur par lieu
+	if strings.Contains(body, "maps.googleapis.com") {
+		t.Error("Google Maps script loaded without an API key")
+	}
+	if !strings.Contains(body, `<svg xmlns="http://www.w3.org/2000/svg" class="world-map"`) {
+		t.Fatal("static map not found")
+	}
+	if n := strings.Count(body, `<circle class="world-marker`); n != len(hostileLocations) {
+		t.Errorf("%d markers on the static map; expected %d", n, len(hostileLocations))
+	}
+	if !strings.Contains(body, `href="/location/london-uk"`) {
+		t.Error("marker link to /location/london-uk missing")
+	}
+
+	// L'endpoint JSON sert les mêmes données
+	rec = httptest.NewRecorder()
+	ArtistAPIHandler(rec, httptest.NewRequest("GET", "/api/v1/artists/1/map", nil))
+	var served MapResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &served); err != nil || len(served.Places) != len(embedded.Places) {
+		t.Errorf("GET /api/v1/artists/1/map = %d %s", rec.Code, rec.Body)
+	}
+	for _, place := range served.Places {
+		if place.Location == "london-uk" && (!place.Located || place.Approximate) {
+			t.Errorf("london-uk = %+v; expected exact coordinates", place)
+		}
+	}
+}
+
+func TestLocalizeSimilar(t *testing.T) {
+	shared := []models.SimilarArtist{{ID: 2, Reasons: []models.SimilarReason{
+		{Key: "similar.created", Value: 1965},
+		{Key: "similar.sameEra"},
+		{Key: "similar.members", Value: 1},
+		{Key: "similar.concerts", Value: 3},
+	}}}
+
+	tests := map[string][]string{
+		"en": {"formed in 1965", "same era", "1 member", "3 shared concerts"},
+		"fr": {"créé en 1965", "même époque", "1 membre", "3 concerts partagés"},
+	}
+	for lang, expected := range tests {
+		localized := localizeSimilar(lang, shared)
+		for i, reason := range localized[0].Reasons {
+			if reason.Text != expected[i] {
+				t.Errorf("%s: %s = %q; expected %q", lang, reason.Key, reason.Text, expected[i])
+			}
+		}
+	}
+	if shared[0].Reasons[0].Text != "" {
+		t.Error("localizeSimilar modified the shared list")
+	}
+}
+
+// Les deux calendriers acceptent GET et HEAD et l'annoncent dans Allow
+func TestICSMethods(t *testing.T) {
+	loadArtistLocations(t, []string{"london-uk"})
+
+	calendars := map[string]http.HandlerFunc{
+		"/artist/1/concerts.ics": ArtistHandler,
+		"/api/v1/concerts.ics":   ConcertsICSHandler,
+	}
+	for path, handler := range calendars {
+		for method, expected := range map[string]int{
+			"GET":    http.StatusOK,
+			"HEAD":   http.StatusOK,
+			"POST":   http.StatusMethodNotAllowed,
+			"DELETE": http.StatusMethodNotAllowed,
+		} {
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(method, path, nil))
+			if rec.Code != expected {
+				t.Errorf("%s %s = %d; expected %d", method, path, rec.Code, expected)
+			}
+			if expected == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != "GET, HEAD" {
+				t.Errorf("%s %s Allow = %q; expected \"GET, HEAD\"", method, path, rec.Header().Get("Allow"))
+			}
+		}
+	}
+}
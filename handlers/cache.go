@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"api-groupie-tracker/api"
+)
+
+// Politiques de cache par famille de routes. Les pages sont revalidées à
+// chaque affichage (304 tant que les données n'ont pas changé) ; les
+// réponses JSON et les fichiers statiques peuvent être réutilisées un temps.
+const (
+	pageCacheControl   = "no-cache"
+	apiCacheControl    = "public, max-age=300"
+	staticCacheControl = "public, max-age=3600"
+)
+
+// cachePolicy retourne l'en-tête Cache-Control de la réponse à r et
+// indique si elle porte un ETag. Un Cache-Control vide laisse la réponse
+// telle quelle (sondes, métriques, requêtes non GET).
+func cachePolicy(r *http.Request) (cacheControl string, etag bool) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return "", false
+	}
+
+	switch path := r.URL.Path; {
+	case path == "/metrics" || path == "/healthz" || path == "/readyz":
+		return "", false
+	case reloadTemplate:
+		// Mode développement : toujours relire templates et fichiers
+		return "no-cache", false
+	case strings.HasPrefix(path, "/static/"):
+		return staticCacheControl, false
+	case isAPIRequest(r):
+		return apiCacheControl, true
+	default:
+		return pageCacheControl, true
+	}
+}
+
+// buildVersion identifie le binaire, pour qu'un déploiement qui change les
+// handlers ou le JSON invalide les ETags. Il peut être fixé à la compilation :
+//
+//	go build -ldflags "-X api-groupie-tracker/handlers.buildVersion=v1.4.0"
+//
+// Sinon, c'est la révision Git enregistrée par go build.
+var buildVersion string
+
+func init() {
+	if buildVersion == "" {
+		buildVersion = readBuildVersion()
+	}
+}
+
+// readBuildVersion retourne la révision Git du binaire, ou à défaut (arbre
+// modifié, go run) l'heure de démarrage : les ETags ne survivent alors pas
+// à un redémarrage.
+func readBuildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		revision, modified := "", false
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				revision = setting.Value
+			case "vcs.modified":
+				modified = setting.Value == "true"
+			}
+		}
+		if revision != "" && !modified {
+			if len(revision) > 12 {
+				revision = revision[:12]
+			}
+			return revision
+		}
+	}
+	return strconv.FormatInt(time.Now().Unix(), 36)
+}
+
+// currentETag identifie la version des réponses : elles ne dépendent que du
+// binaire, du jeu de données, des templates, de la langue et du jour (dates
+// relatives "dans 3 semaines" des pages). ETag faible, la réponse pouvant
+// être compressée ou non.
+func currentETag(lang string) string {
+	version := api.GetDataVersion()
+	if version == "" {
+		return ""
+	}
+	day := time.Now().UTC().Format("20060102")
+	return `W/"` + buildVersion + "-" + version + "-" + templateVersion + "-" + lang + "-" + day + `"`
+}
+
+// etagMatches indique si l'en-tête If-None-Match désigne etag. La
+// comparaison est faible : W/"x" et "x" sont équivalents. "*" n'est pas
+// retenu : il vaut pour toute ressource existante, et Cache répond avant de
+// savoir si la ressource existe.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Cache ajoute Cache-Control et ETag aux réponses réussies, et répond 304
+// sans exécuter le handler si le client a déjà la version courante
+func Cache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cacheControl, useETag := cachePolicy(r)
+		if cacheControl == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var etag string
+		if useETag {
+			etag = currentETag(requestLang(r))
+		}
+		if etag != "" && etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Cache-Control", cacheControl)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		next.ServeHTTP(&cacheWriter{ResponseWriter: w, etag: etag, cacheControl: cacheControl}, r)
+	})
+}
+
+/*
+	cacheWriter
+	➡️ pose les en-têtes de cache juste avant l'envoi d'une réponse 200
+*/
+type cacheWriter struct {
+	http.ResponseWriter
+	etag         string
+	cacheControl string
+	wroteHeader  bool
+}
+
+func (cw *cacheWriter) WriteHeader(status int) {
+	if !cw.wroteHeader {
+		cw.wroteHeader = true
+
+		// Un handler qui fixe son propre Cache-Control garde la main
+		h := cw.Header()
+		if status == http.StatusOK && h.Get("Cache-Control") == "" {
+			h.Set("Cache-Control", cw.cacheControl)
+			if cw.etag != "" {
+				h.Set("ETag", cw.etag)
+			}
+		}
+	}
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+func (cw *cacheWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+func (cw *cacheWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api-groupie-tracker/i18n"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"", ""},
+		{"gzip, deflate, br", "gzip"},
+		{"deflate", "deflate"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"gzip;q=0, *", "deflate"},
+		{"*", "gzip"},
+		{"br, identity", ""},
+		{"GZIP;q=0.8", "gzip"},
+	}
+
+	for _, test := range tests {
+		if got := negotiateEncoding(test.header); got != test.expected {
+			t.Errorf("negotiateEncoding(%q) = %q; expected %q", test.header, got, test.expected)
+		}
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `W/"abc-123"`
+	for header, expected := range map[string]bool{
+		`W/"abc-123"`:        true,
+		`"abc-123"`:          true,
+		`"old", W/"abc-123"`: true,
+		`*`:                  false,
+		`W/"abc-124"`:        false,
+		``:                   false,
+	} {
+		if got := etagMatches(header, etag); got != expected {
+			t.Errorf("etagMatches(%q) = %v; expected %v", header, got, expected)
+		}
+	}
+}
+
+func TestCurrentETagIncludesBuild(t *testing.T) {
+	loadTestData(t, time.Now())
+	previous := buildVersion
+	t.Cleanup(func() { buildVersion = previous })
+
+	buildVersion = "build-a"
+	before := currentETag(i18n.Default)
+	buildVersion = "build-b"
+	if after := currentETag(i18n.Default); after == before || !strings.Contains(after, "build-b") {
+		t.Errorf("ETag %s after a new build; expected it to change from %s", after, before)
+	}
+}
+
+// If-None-Match: * ne transforme pas une page introuvable en 304
+func TestCacheIgnoresWildcard(t *testing.T) {
+	loadTestData(t, time.Now())
+
+	req := httptest.NewRequest("GET", "/nowhere", nil)
+	req.Header.Set("If-None-Match", "*")
+	rec := httptest.NewRecorder()
+	Chain(http.NotFoundHandler(), Cache).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /nowhere with If-None-Match: * = %d; expected 404", rec.Code)
+	}
+}
+
+func TestCacheAndCompress(t *testing.T) {
+	loadTestData(t, time.Now())
+
+	calls := 0
+	page := strings.Repeat("<p>Queen</p>\n", 200)
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, page)
+	}), Compress, Cache)
+
+	req := httptest.NewRequest("GET", "/artist/1", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" || rec.Header().Get("Cache-Control") != pageCacheControl {
+		t.Fatalf("GET = %d, ETag %q, Cache-Control %q", rec.Code, etag, rec.Header().Get("Cache-Control"))
+	}
+	if rec.Header().Get("Content-Encoding") != "gzip" || rec.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("headers = %v; expected a gzip response varying on Accept-Encoding", rec.Header())
+	}
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, err := io.ReadAll(gz); err != nil || string(body) != page {
+		t.Errorf("decompressed body differs (%v)", err)
+	}
+
+	// Même version : 304 sans exécuter le handler
+	req = httptest.NewRequest("GET", "/artist/1", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 || calls != 1 {
+		t.Errorf("conditional GET = %d (%d bytes, %d calls); expected an empty 304", rec.Code, rec.Body.Len(), calls)
+	}
+
+	// Un rechargement au contenu identique garde la même version
+	loadTestData(t, time.Now().Add(time.Hour))
+	if current := currentETag(i18n.Default); current != etag {
+		t.Errorf("ETag after reloading the same data = %s; expected %s", current, etag)
+	}
+
+	// Les sondes ne sont ni mises en cache ni compressées si trop petites
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/healthz", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	Chain(http.HandlerFunc(HealthHandler), Compress, Cache).ServeHTTP(rec, req)
+	if rec.Header().Get("ETag") != "" || rec.Header().Get("Content-Encoding") != "" {
+		t.Errorf("/healthz headers = %v; expected no ETag and no compression", rec.Header())
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/models"
+	"api-groupie-tracker/utils"
+)
+
+/*
+	CalendarPageData
+	➡️ STRUCT pour calendar.html
+	➡️ YearView : vue annuelle (12 mois) sinon vue mensuelle
+*/
+type CalendarPageData struct {
+	YearView bool
+	Year     int
+	Month    models.CalendarMonth
+	Months   []models.CalendarMonth
+
+	PrevURL string
+	NextURL string
+}
+
+// =======================
+// CALENDAR
+// =======================
+func CalendarHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ErrorHandler(w, r, http.StatusMethodNotAllowed)
+		return
+	}
+
+	concerts := api.GetConcerts()
+	year, month := defaultCalendarMonth(concerts)
+
+	q := r.URL.Query()
+	if v := q.Get("year"); v != "" {
+		y, err := strconv.Atoi(v)
+		if err != nil || y < 1 || y > 9999 {
+			ErrorHandler(w, r, http.StatusBadRequest)
+			return
+		}
+		year = y
+	}
+
+	data := CalendarPageData{Year: year}
+
+	if q.Get("view") == "year" {
+		data.YearView = true
+		for m := time.January; m <= time.December; m++ {
+			data.Months = append(data.Months, utils.BuildCalendarMonth(concerts, year, m))
+		}
+		data.PrevURL = "/calendar?view=year&year=" + strconv.Itoa(year-1)
+		data.NextURL = "/calendar?view=year&year=" + strconv.Itoa(year+1)
+	} else {
+		if v := q.Get("month"); v != "" {
+			m, err := strconv.Atoi(v)
+			if err != nil || m < 1 || m > 12 {
+				ErrorHandler(w, r, http.StatusBadRequest)
+				return
+			}
+			month = time.Month(m)
+		}
+		data.Month = utils.BuildCalendarMonth(concerts, year, month)
+
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		data.PrevURL = calendarMonthURL(first.AddDate(0, -1, 0))
+		data.NextURL = calendarMonthURL(first.AddDate(0, 1, 0))
+	}
+
+	render(w, r, "calendar.html", data)
+}
+
+// defaultCalendarMonth retourne le mois courant s'il contient des concerts,
+// sinon le mois du dernier concert connu
+func defaultCalendarMonth(concerts []models.Concert) (int, time.Month) {
+	now := time.Now().UTC()
+	if len(concerts) == 0 {
+		return now.Year(), now.Month()
+	}
+
+	for _, concert := range concerts {
+		if concert.Date.Year() == now.Year() && concert.Date.Month() == now.Month() {
+			return now.Year(), now.Month()
+		}
+	}
+
+	last := concerts[len(concerts)-1].Date
+	return last.Year(), last.Month()
+}
+
+func calendarMonthURL(t time.Time) string {
+	return "/calendar?year=" + strconv.Itoa(t.Year()) + "&month=" + strconv.Itoa(int(t.Month()))
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Taille minimale d'une réponse compressée : en dessous, l'en-tête gzip
+// coûte plus qu'il ne rapporte
+const minCompressSize = 1024
+
+// Types de contenu compressés ; les images et polices le sont déjà
+var compressibleTypes = []string{
+	"text/",
+	"application/json",
+	"application/problem+json",
+	"application/xml",
+	"application/atom+xml",
+	"application/javascript",
+	"image/svg+xml",
+}
+
+var (
+	gzipWriters  = sync.Pool{New: func() interface{} { w, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression); return w }}
+	flateWriters = sync.Pool{New: func() interface{} { w, _ := flate.NewWriter(nil, flate.DefaultCompression); return w }}
+)
+
+// negotiateEncoding choisit gzip ou deflate d'après Accept-Encoding, en
+// respectant les poids q. Retourne "" si aucun des deux n'est accepté.
+func negotiateEncoding(header string) string {
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		weights[name] = q
+	}
+
+	// Un codage non cité prend le poids de "*", 0 par défaut
+	weight := func(encoding string) float64 {
+		if q, ok := weights[encoding]; ok {
+			return q
+		}
+		return weights["*"]
+	}
+
+	// À poids égal, gzip est préféré
+	gz, df := weight("gzip"), weight("deflate")
+	switch {
+	case gz > 0 && gz >= df:
+		return "gzip"
+	case df > 0:
+		return "deflate"
+	}
+	return ""
+}
+
+func compressible(contentType string) bool {
+	for _, prefix := range compressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compress compresse les réponses textuelles en gzip ou deflate selon
+// l'en-tête Accept-Encoding du client
+func Compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Les requêtes partielles portent sur les octets non compressés
+		if r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		done := false
+		defer func() {
+			// Après une panique, la réponse retenue est abandonnée : Recover,
+			// plus haut dans la chaîne, répond 500 à la place
+			if !done && !cw.decided {
+				cw.decided, cw.buf = true, nil
+			}
+			cw.Close()
+		}()
+		next.ServeHTTP(cw, r)
+		done = true
+	})
+}
+
+/*
+	compressWriter
+	➡️ retient le début de la réponse pour décider s'il faut la compresser,
+	   puis compresse le corps au fil de l'écriture
+*/
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	status   int
+	buf      []byte
+	decided  bool
+	writer   io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status == 0 && !cw.decided {
+		cw.status = status
+	}
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	if !cw.decided {
+		cw.buf = append(cw.buf, b...)
+		if len(cw.buf) < minCompressSize {
+			return len(b), nil
+		}
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if cw.writer != nil {
+		return cw.writer.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// decide envoie les en-têtes, compressés si allow et si la réponse s'y
+// prête, puis le début de corps retenu
+func (cw *compressWriter) decide(allow bool) error {
+	cw.decided = true
+	if cw.status == 0 {
+		return nil
+	}
+
+	h := cw.Header()
+	if h.Get("Content-Type") == "" && len(cw.buf) > 0 {
+		h.Set("Content-Type", http.DetectContentType(cw.buf))
+	}
+	bodyless := cw.status == http.StatusNoContent || cw.status == http.StatusNotModified
+	if allow && !bodyless && h.Get("Content-Encoding") == "" && compressible(h.Get("Content-Type")) {
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", cw.encoding)
+		if cw.encoding == "gzip" {
+			gz := gzipWriters.Get().(*gzip.Writer)
+			gz.Reset(cw.ResponseWriter)
+			cw.writer = gz
+		} else {
+			fl := flateWriters.Get().(*flate.Writer)
+			fl.Reset(cw.ResponseWriter)
+			cw.writer = fl
+		}
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if cw.writer != nil {
+		_, err = cw.writer.Write(buf)
+	} else {
+		_, err = cw.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// Flush envoie ce qui est retenu : une réponse diffusée au fil de l'eau est
+// compressée dès le premier Flush
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		cw.decide(true)
+	}
+	switch w := cw.writer.(type) {
+	case *gzip.Writer:
+		w.Flush()
+	case *flate.Writer:
+		w.Flush()
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Close termine la réponse : une réponse courte restée en tampon part non
+// compressée. Le compresseur est rendu au pool.
+func (cw *compressWriter) Close() {
+	if !cw.decided {
+		cw.decide(false)
+	}
+	if cw.writer == nil {
+		return
+	}
+	cw.writer.Close()
+	switch w := cw.writer.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(w)
+	case *flate.Writer:
+		flateWriters.Put(w)
+	}
+	cw.writer = nil
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/models"
+)
+
+// Format des dates passées en paramètre d'URL (champ <input type="date">)
+const queryDateLayout = "2006-01-02"
+
+// Rayon par défaut et maximal de la recherche (demi-circonférence terrestre)
+const (
+	defaultRadiusKm = 100.0
+	maxRadiusKm     = 20038.0
+)
+
+// NearbyResponse est la réponse de /api/v1/concerts/nearby
+type NearbyResponse struct {
+	Lat      float64                `json:"lat"`
+	Lon      float64                `json:"lon"`
+	RadiusKm float64                `json:"radiusKm"`
+	Count    int                    `json:"count"`
+	Concerts []models.NearbyConcert `json:"concerts"`
+}
+
+// =======================
+// NEARBY CONCERTS
+// =======================
+func NearbyConcertsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	criteria, err := parseNearbyCriteria(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	concerts := api.GetNearbyConcerts(criteria)
+
+	writeJSON(w, http.StatusOK, NearbyResponse{
+		Lat:      criteria.Lat,
+		Lon:      criteria.Lon,
+		RadiusKm: criteria.RadiusKm,
+		Count:    len(concerts),
+		Concerts: concerts,
+	})
+}
+
+// parseNearbyCriteria lit lat/lon (ou une location connue), radius_km, from
+// et to. L'erreur retournée est un *Error 400 prêt à être affiché.
+func parseNearbyCriteria(r *http.Request) (models.NearbyCriteria, error) {
+	q := r.URL.Query()
+	criteria := models.NearbyCriteria{RadiusKm: defaultRadiusKm}
+
+	latStr, lonStr := strings.TrimSpace(q.Get("lat")), strings.TrimSpace(q.Get("lon"))
+	location := strings.TrimSpace(q.Get("location"))
+
+	switch {
+	case latStr != "" || lonStr != "":
+		lat, err := strconv.ParseFloat(latStr, 64)
+		if err != nil || lat < -90 || lat > 90 {
+			return criteria, newError(http.StatusBadRequest, "error.latRange")
+		}
+		lon, err := strconv.ParseFloat(lonStr, 64)
+		if err != nil || lon < -180 || lon > 180 {
+			return criteria, newError(http.StatusBadRequest, "error.lonRange")
+		}
+		criteria.Lat, criteria.Lon = lat, lon
+	case location != "":
+		p, ok := api.GetLocationPosition(location)
+		if !ok {
+			return criteria, newError(http.StatusBadRequest, "error.unknownLocation", location)
+		}
+		criteria.Lat, criteria.Lon = p.Lat, p.Lon
+	default:
+		return criteria, newError(http.StatusBadRequest, "error.missingPosition")
+	}
+
+	if v := strings.TrimSpace(q.Get("radius_km")); v != "" {
+		radius, err := strconv.ParseFloat(v, 64)
+		if err != nil || radius <= 0 || radius > maxRadiusKm {
+			return criteria, newError(http.StatusBadRequest, "error.radiusRange", maxRadiusKm)
+		}
+		criteria.RadiusKm = radius
+	}
+
+	var err error
+	if criteria.From, err = parseQueryDate(q.Get("from")); err != nil {
+		return criteria, newError(http.StatusBadRequest, "error.dateFormat", "from")
+	}
+	if criteria.To, err = parseQueryDate(q.Get("to")); err != nil {
+		return criteria, newError(http.StatusBadRequest, "error.dateFormat", "to")
+	}
+	if !criteria.From.IsZero() && !criteria.To.IsZero() && criteria.To.Before(criteria.From) {
+		return criteria, newError(http.StatusBadRequest, "error.dateOrder")
+	}
+
+	return criteria, nil
+}
+
+// parseQueryDate lit une date AAAA-MM-JJ ; une valeur vide donne la date zéro
+func parseQueryDate(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(queryDateLayout, v)
+}
+
+// parseConcertCriteria lit les filtres artist (répétable), location, country,
+// from et to. L'erreur retournée est un *Error 400 prêt à être affiché.
+func parseConcertCriteria(r *http.Request) (models.ConcertCriteria, error) {
+	q := r.URL.Query()
+	criteria := models.ConcertCriteria{
+		Location: strings.TrimSpace(q.Get("location")),
+		Country:  strings.TrimSpace(q.Get("country")),
+	}
+
+	for _, v := range q["artist"] {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return criteria, newError(http.StatusBadRequest, "error.artistParam")
+		}
+		criteria.ArtistIDs = append(criteria.ArtistIDs, id)
+	}
+
+	var err error
+	if criteria.From, err = parseQueryDate(q.Get("from")); err != nil {
+		return criteria, newError(http.StatusBadRequest, "error.dateFormat", "from")
+	}
+	if criteria.To, err = parseQueryDate(q.Get("to")); err != nil {
+		return criteria, newError(http.StatusBadRequest, "error.dateFormat", "to")
+	}
+
+	return criteria, nil
+}
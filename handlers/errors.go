@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api-groupie-tracker/i18n"
+)
+
+// Error est une erreur de handler : le statut HTTP, le message montré à
+// l'utilisateur et la cause interne, journalisée mais jamais affichée.
+// Message est une clé du catalogue i18n, traduite avec Args à l'affichage.
+type Error struct {
+	Status  int
+	Message string
+	Args    []interface{}
+	Cause   error
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%d %s: %v", e.Status, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("%d %s", e.Status, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// newError crée une erreur destinée à l'utilisateur, sans cause interne
+func newError(status int, message string, args ...interface{}) *Error {
+	return &Error{Status: status, Message: message, Args: args}
+}
+
+// internalError enveloppe une erreur inattendue : l'utilisateur ne voit
+// que le message générique du statut 500
+func internalError(cause error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Cause: cause}
+}
+
+// errorMessage traduit le message de e, ou le message par défaut du statut
+// ("error.status.404") lorsque l'erreur n'en précise pas
+func errorMessage(lang string, e *Error) string {
+	if e.Message != "" && e.Status < http.StatusInternalServerError {
+		return i18n.T(lang, e.Message, e.Args...)
+	}
+	key := "error.status." + strconv.Itoa(e.Status)
+	if message := i18n.T(lang, key); message != key {
+		return message
+	}
+	return http.StatusText(e.Status)
+}
+
+/*
+	Problem
+	➡️ corps des erreurs des routes /api/ (RFC 9457, application/problem+json)
+*/
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// isAPIRequest indique si la réponse attendue est du JSON plutôt qu'une page
+func isAPIRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, "/api/") || r.URL.Path == "/graphql"
+}
+
+// writeError répond avec la page d'erreur, ou un problem+json pour les
+// routes /api/. Une erreur qui n'est pas un *Error est traitée comme une
+// erreur interne.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		e = internalError(err)
+	}
+
+	lang := requestLang(r)
+	message := errorMessage(lang, e)
+
+	if e.Cause != nil {
+		Logger(r.Context()).Error(message,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", e.Status,
+			"error", e.Cause,
+		)
+	}
+
+	if isAPIRequest(r) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(e.Status)
+		json.NewEncoder(w).Encode(Problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(e.Status),
+			Status:   e.Status,
+			Detail:   message,
+			Instance: r.URL.Path,
+		})
+		return
+	}
+
+	data := struct {
+		Code    int
+		Message string
+	}{
+		Code:    e.Status,
+		Message: message,
+	}
+
+	var buf bytes.Buffer
+	if err := executeTemplate(&buf, lang, "error.html", data); err != nil {
+		Logger(r.Context()).Error("rendu de la page d'erreur", "path", r.URL.Path, "error", err)
+		http.Error(w, i18n.T(lang, "error.title", e.Status), e.Status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(e.Status)
+	w.Write(buf.Bytes())
+}
+
+// render rend un template dans un tampon avant de l'envoyer : une erreur de
+// rendu donne une page d'erreur complète plutôt qu'une page tronquée
+func render(w http.ResponseWriter, r *http.Request, name string, data interface{}) {
+	var buf bytes.Buffer
+	if err := executeTemplate(&buf, requestLang(r), name, data); err != nil {
+		writeError(w, r, internalError(fmt.Errorf("template %s: %w", name, err)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// =======================
+// ERROR
+// =======================
+func ErrorHandler(w http.ResponseWriter, r *http.Request, status int) {
+	writeError(w, r, newError(status, ""))
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/models"
+	"api-groupie-tracker/utils"
+)
+
+// Nombre de lignes CSV écrites entre deux envois au client
+const exportFlushEvery = 100
+
+// =======================
+// EXPORT
+// =======================
+
+// ExportHandler sert /api/v1/export/{artists,concerts}.{csv,ndjson}.
+// La sélection suit les paramètres de SearchHandler (q) et de FilterHandler.
+func ExportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/v1/export/")
+	dataset, format, _ := strings.Cut(name, ".")
+	if (dataset != "artists" && dataset != "concerts") || (format != "csv" && format != "ndjson") {
+		writeError(w, r, newError(http.StatusNotFound, "error.unknownExport", name))
+		return
+	}
+
+	artists := selectArtists(r)
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+
+	if dataset == "artists" {
+		exportArtists(w, format, artists)
+		return
+	}
+
+	criteria, err := parseConcertCriteria(r)
+	if err != nil {
+		w.Header().Del("Content-Disposition")
+		writeError(w, r, err)
+		return
+	}
+	criteria.ArtistIDs = restrictArtistIDs(artists, criteria.ArtistIDs)
+
+	var concerts []models.Concert
+	if len(criteria.ArtistIDs) > 0 {
+		concerts = utils.FilterConcerts(api.GetConcerts(), criteria)
+	}
+	exportConcerts(w, format, concerts)
+}
+
+// selectArtists applique la recherche (q) puis les filtres de FilterHandler
+func selectArtists(r *http.Request) []models.FullArtist {
+	artists := api.GetAllArtists()
+
+	if query := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q"))); query != "" {
+		found := searchArtists(artists, query)
+		artists = make([]models.Artist, 0, len(found))
+		for _, full := range found {
+			artists = append(artists, full.Artist)
+		}
+	}
+
+	return filterArtists(artists, parseFilterCriteria(r))
+}
+
+// restrictArtistIDs limite la sélection aux artistes demandés par ?artist=
+func restrictArtistIDs(artists []models.FullArtist, requested []int) []int {
+	wanted := make(map[int]bool, len(requested))
+	for _, id := range requested {
+		wanted[id] = true
+	}
+
+	var ids []int
+	for _, artist := range artists {
+		if len(wanted) == 0 || wanted[artist.ID] {
+			ids = append(ids, artist.ID)
+		}
+	}
+	return ids
+}
+
+// artistRecord est une ligne de l'export NDJSON des artistes : l'artiste
+// tel que fourni par l'API, plus la date du premier album au format ISO
+type artistRecord struct {
+	models.Artist
+	FirstAlbumDate models.LocalDate `json:"firstAlbumDate"`
+}
+
+func exportArtists(w http.ResponseWriter, format string, artists []models.FullArtist) {
+	if format == "ndjson" {
+		enc := json.NewEncoder(w)
+		for _, artist := range artists {
+			record := artistRecord{Artist: artist.Artist, FirstAlbumDate: utils.ParseLocalDate(artist.FirstAlbum)}
+			if err := enc.Encode(record); err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"id", "name", "members", "member_count", "creation_date", "first_album", "first_album_year", "image"})
+	for i, artist := range artists {
+		cw.Write([]string{
+			strconv.Itoa(artist.ID),
+			artist.Name,
+			strings.Join(artist.Members, "; "),
+			strconv.Itoa(len(artist.Members)),
+			strconv.Itoa(artist.CreationDate),
+			artist.FirstAlbum,
+			strconv.Itoa(utils.ExtractYear(artist.FirstAlbum)),
+			artist.Image,
+		})
+		if (i+1)%exportFlushEvery == 0 {
+			if cw.Flush(); cw.Error() != nil {
+				return
+			}
+		}
+	}
+	cw.Flush()
+}
+
+func exportConcerts(w http.ResponseWriter, format string, concerts []models.Concert) {
+	if format == "ndjson" {
+		enc := json.NewEncoder(w)
+		for _, concert := range concerts {
+			if err := enc.Encode(concert); err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"artist_id", "artist_name", "location", "country", "date", "latitude", "longitude"})
+	for i, concert := range concerts {
+		lat, lon := "", ""
+		if p, ok := api.GetLocationPosition(concert.Location); ok {
+			lat = strconv.FormatFloat(p.Lat, 'f', 4, 64)
+			lon = strconv.FormatFloat(p.Lon, 'f', 4, 64)
+		}
+		cw.Write([]string{
+			strconv.Itoa(concert.ArtistID),
+			concert.ArtistName,
+			concert.Location,
+			utils.LocationCountry(concert.Location),
+			concert.Date.Format(queryDateLayout),
+			lat,
+			lon,
+		})
+		if (i+1)%exportFlushEvery == 0 {
+			if cw.Flush(); cw.Error() != nil {
+				return
+			}
+		}
+	}
+	cw.Flush()
+}
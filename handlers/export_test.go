@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Les liens d'export reprennent tous les filtres de la page affichée
+func TestExportLinksKeepFilters(t *testing.T) {
+	loadArtistLocations(t, []string{"london-uk"})
+
+	tests := []struct {
+		method, target, body string
+		expected             string
+	}{
+		{"POST", "/filter", "creation_min=1970&album_max=1980&members_min=2&locations=london-uk",
+			`/api/v1/export/concerts.csv?album_max=1980&amp;creation_min=1970&amp;locations=london-uk&amp;members_min=2"`},
+		{"GET", "/filter?creation_max=1975&locations=london-uk&locations=paris-france", "",
+			`/api/v1/export/artists.ndjson?creation_max=1975&amp;locations=london-uk&amp;locations=paris-france"`},
+		{"GET", "/search?q=queen", "", `/api/v1/export/artists.csv?q=queen"`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		if strings.HasPrefix(tt.target, "/search") {
+			SearchHandler(rec, req)
+		} else {
+			FilterHandler(rec, req)
+		}
+		if !strings.Contains(rec.Body.String(), tt.expected) {
+			t.Errorf("%s %s: export link %s not found", tt.method, tt.target, tt.expected)
+		}
+	}
+}
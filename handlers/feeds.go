@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/utils"
+)
+
+// Structures Atom (RFC 4287)
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title   string     `xml:"title"`
+	ID      string     `xml:"id"`
+	Updated string     `xml:"updated"`
+	Links   []atomLink `xml:"link"`
+	Summary string     `xml:"summary"`
+}
+
+// =======================
+// ATOM FEEDS
+// =======================
+
+// FeedsHandler sert /feeds/artists.atom et /feeds/concerts.atom,
+// filtrables par ?artist={id} ou ?country={pays}
+func FeedsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ErrorHandler(w, r, http.StatusMethodNotAllowed)
+		return
+	}
+
+	lang := requestLang(r)
+	var kind, title string
+	switch strings.TrimPrefix(r.URL.Path, "/feeds/") {
+	case "artists.atom":
+		kind, title = utils.FeedKindArtist, i18n.T(lang, "feed.artists")
+	case "concerts.atom":
+		kind, title = utils.FeedKindConcert, i18n.T(lang, "feed.concerts")
+	default:
+		ErrorHandler(w, r, http.StatusNotFound)
+		return
+	}
+
+	q := r.URL.Query()
+	artistID := 0
+	if v := q.Get("artist"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			ErrorHandler(w, r, http.StatusBadRequest)
+			return
+		}
+		artist, err := api.GetArtistByID(id)
+		if err != nil {
+			ErrorHandler(w, r, http.StatusNotFound)
+			return
+		}
+		artistID = id
+		title += " - " + artist.Name
+	}
+	country := strings.TrimSpace(q.Get("country"))
+	if country != "" {
+		title += " - " + utils.FormatLocation(country)
+	}
+
+	entries := utils.FilterFeed(api.GetFeedEntries(), kind, artistID, country)
+	base := baseURL(r)
+
+	updated := api.GetLastRefresh()
+	if len(entries) > 0 {
+		updated = entries[0].Added
+	}
+
+	self := base + r.URL.Path
+	if r.URL.RawQuery != "" {
+		self += "?" + r.URL.RawQuery
+	}
+
+	feed := atomFeed{
+		Title:   "Groupie Tracker - " + title,
+		ID:      feedID(kind, artistID, country),
+		Updated: updated.Format(time.RFC3339),
+		Author:  atomAuthor{Name: "Groupie Tracker"},
+		Links: []atomLink{
+			{Href: self, Rel: "self", Type: "application/atom+xml"},
+			{Href: base + "/", Rel: "alternate", Type: "text/html"},
+		},
+	}
+
+	for _, entry := range entries {
+		link := fmt.Sprintf("%s/artist/%d", base, entry.ArtistID)
+		e := atomEntry{
+			ID:      entry.ID,
+			Updated: entry.Added.Format(time.RFC3339),
+			Links:   []atomLink{{Href: link, Rel: "alternate", Type: "text/html"}},
+		}
+
+		if entry.Kind == utils.FeedKindArtist {
+			e.Title = i18n.T(lang, "feed.newArtist", entry.ArtistName)
+			e.Summary = i18n.T(lang, "feed.artistAdded", entry.ArtistName)
+		} else {
+			place := utils.FormatLocation(entry.Location)
+			e.Title = fmt.Sprintf("%s - %s, %s", entry.ArtistName, place, entry.Date.Format(utils.DateLayout))
+			e.Summary = i18n.T(lang, "feed.newConcert", entry.ArtistName, place, i18n.FormatDate(lang, entry.Date))
+		}
+
+		feed.Entries = append(feed.Entries, e)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		writeError(w, r, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// feedID retourne un identifiant de flux stable pour une portée donnée
+func feedID(kind string, artistID int, country string) string {
+	id := "urn:groupie-tracker:feed:" + kind
+	if artistID > 0 {
+		id += ":artist:" + strconv.Itoa(artistID)
+	}
+	if country != "" {
+		id += ":country:" + url.PathEscape(utils.NormalizeLocation(country))
+	}
+	return id
+}
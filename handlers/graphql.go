@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/graphql"
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/models"
+	"api-groupie-tracker/utils"
+)
+
+const (
+	// Profondeur maximale d'une requête : artist { concerts { location {
+	// artists { similar { members { name } } } } } } tient en 7 niveaux
+	graphQLMaxDepth = 8
+
+	// Nombre maximal de champs résolus par requête
+	graphQLMaxFields = 50000
+
+	// Taille maximale du corps d'une requête POST
+	graphQLMaxBody = 64 << 10
+)
+
+// graphMember est un membre d'un groupe, rattaché à son artiste
+type graphMember struct {
+	Name     string
+	ArtistID int
+}
+
+// graphLocation est une location telle que fournie par l'API ("lyon-france")
+type graphLocation string
+
+// graphQLSchema expose le graphe artistes ➡️ concerts ➡️ locations :
+//
+//	type Query {
+//		artist(id: Int!): Artist
+//		artists(name: String, limit: Int): [Artist!]!
+//		concerts(artist: [Int!], location: String, country: String, from: String, to: String): [Concert!]!
+//		location(name: String!): Location
+//		locations(country: String): [Location!]!
+//	}
+//	type Artist   { id name image creationDate firstAlbum firstAlbumDate members concerts locations similar }
+//	type Member   { name artist }
+//	type Concert  { date artist location }
+//	type Location { name displayName country latitude longitude concerts artists }
+var graphQLSchema = newGraphQLSchema()
+
+func newGraphQLSchema() *graphql.Schema {
+	artist := &graphql.Object{Name: "Artist"}
+	member := &graphql.Object{Name: "Member"}
+	concert := &graphql.Object{Name: "Concert"}
+	location := &graphql.Object{Name: "Location"}
+
+	artist.Fields = map[string]*graphql.FieldDef{
+		"id": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(models.Artist).ID, nil
+		}},
+		"name": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(models.Artist).Name, nil
+		}},
+		"image": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(models.Artist).Image, nil
+		}},
+		"creationDate": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(models.Artist).CreationDate, nil
+		}},
+		"firstAlbum": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(models.Artist).FirstAlbum, nil
+		}},
+		"firstAlbumDate": {Type: "String", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			// Date ISO, null si la date de l'API est illisible
+			date := utils.ParseLocalDate(p.Source.(models.Artist).FirstAlbum)
+			if date.IsZero() {
+				return nil, nil
+			}
+			return date.Format(models.ISODateLayout), nil
+		}},
+		"members": {Type: "[Member!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			a := p.Source.(models.Artist)
+			members := make([]graphMember, len(a.Members))
+			for i, name := range a.Members {
+				members[i] = graphMember{Name: name, ArtistID: a.ID}
+			}
+			return members, nil
+		}},
+		"concerts": {Type: "[Concert!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id := p.Source.(models.Artist).ID
+			return utils.FilterConcerts(api.GetConcerts(), models.ConcertCriteria{ArtistIDs: []int{id}}), nil
+		}},
+		"locations": {Type: "[Location!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id := p.Source.(models.Artist).ID
+			concerts := utils.FilterConcerts(api.GetConcerts(), models.ConcertCriteria{ArtistIDs: []int{id}})
+
+			seen := make(map[string]bool)
+			var locations []graphLocation
+			for _, c := range concerts {
+				if !seen[c.Location] {
+					seen[c.Location] = true
+					locations = append(locations, graphLocation(c.Location))
+				}
+			}
+			return locations, nil
+		}},
+		"similar": {Type: "[Artist!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var artists []models.Artist
+			for _, s := range api.GetSimilar(p.Source.(models.Artist).ID) {
+				if a, err := api.GetArtistByID(s.ID); err == nil {
+					artists = append(artists, *a)
+				}
+			}
+			return artists, nil
+		}},
+	}
+
+	member.Fields = map[string]*graphql.FieldDef{
+		"name": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(graphMember).Name, nil
+		}},
+		"artist": {Type: "Artist!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return resolveArtist(p.Source.(graphMember).ArtistID)
+		}},
+	}
+
+	concert.Fields = map[string]*graphql.FieldDef{
+		"date": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(models.Concert).Date.Format(queryDateLayout), nil
+		}},
+		"artist": {Type: "Artist!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return resolveArtist(p.Source.(models.Concert).ArtistID)
+		}},
+		"location": {Type: "Location!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return graphLocation(p.Source.(models.Concert).Location), nil
+		}},
+	}
+
+	location.Fields = map[string]*graphql.FieldDef{
+		"name": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return string(p.Source.(graphLocation)), nil
+		}},
+		"displayName": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return utils.FormatLocation(string(p.Source.(graphLocation))), nil
+		}},
+		"country": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return utils.LocationCountry(string(p.Source.(graphLocation))), nil
+		}},
+		"latitude": {Type: "Float", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if pos, ok := api.GetLocationPosition(string(p.Source.(graphLocation))); ok {
+				return pos.Lat, nil
+			}
+			return nil, nil
+		}},
+		"longitude": {Type: "Float", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if pos, ok := api.GetLocationPosition(string(p.Source.(graphLocation))); ok {
+				return pos.Lon, nil
+			}
+			return nil, nil
+		}},
+		"concerts": {Type: "[Concert!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return api.GetLocationConcerts(string(p.Source.(graphLocation))), nil
+		}},
+		"artists": {Type: "[Artist!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return locationArtists(api.GetLocationConcerts(string(p.Source.(graphLocation)))), nil
+		}},
+	}
+
+	query := &graphql.Object{Name: "Query", Fields: map[string]*graphql.FieldDef{
+		"artist": {
+			Type: "Artist",
+			Args: map[string]string{"id": "Int!"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				a, err := api.GetArtistByID(p.Args["id"].(int))
+				if err != nil {
+					return nil, nil
+				}
+				return *a, nil
+			},
+		},
+		"artists": {
+			Type: "[Artist!]!",
+			Args: map[string]string{"name": "String", "limit": "Int"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				name, _ := p.Args["name"].(string)
+				name = strings.ToLower(strings.TrimSpace(name))
+
+				var artists []models.Artist
+				for _, a := range api.GetAllArtists() {
+					if name == "" || strings.Contains(strings.ToLower(a.Name), name) {
+						artists = append(artists, a)
+					}
+				}
+				if limit, ok := p.Args["limit"].(int); ok && limit >= 0 && limit < len(artists) {
+					artists = artists[:limit]
+				}
+				return artists, nil
+			},
+		},
+		"concerts": {
+			Type: "[Concert!]!",
+			Args: map[string]string{
+				"artist":   "[Int!]",
+				"location": "String",
+				"country":  "String",
+				"from":     "String",
+				"to":       "String",
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				var criteria models.ConcertCriteria
+				if ids, ok := p.Args["artist"].([]interface{}); ok {
+					for _, id := range ids {
+						criteria.ArtistIDs = append(criteria.ArtistIDs, id.(int))
+					}
+				}
+				criteria.Location, _ = p.Args["location"].(string)
+				criteria.Country, _ = p.Args["country"].(string)
+
+				var err error
+				from, _ := p.Args["from"].(string)
+				if criteria.From, err = parseQueryDate(from); err != nil {
+					return nil, errors.New(i18n.T(contextLang(p.Context), "error.dateFormat", "from"))
+				}
+				to, _ := p.Args["to"].(string)
+				if criteria.To, err = parseQueryDate(to); err != nil {
+					return nil, errors.New(i18n.T(contextLang(p.Context), "error.dateFormat", "to"))
+				}
+
+				return utils.FilterConcerts(api.GetConcerts(), criteria), nil
+			},
+		},
+		"location": {
+			Type: "Location",
+			Args: map[string]string{"name": "String!"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				concerts := api.GetLocationConcerts(p.Args["name"].(string))
+				if len(concerts) == 0 {
+					return nil, nil
+				}
+				return graphLocation(concerts[0].Location), nil
+			},
+		},
+		"locations": {
+			Type: "[Location!]!",
+			Args: map[string]string{"country": "String"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				country, _ := p.Args["country"].(string)
+				country = utils.NormalizeLocation(country)
+
+				var locations []graphLocation
+				for _, name := range api.GetLocationNames() {
+					if country == "" || utils.NormalizeLocation(utils.LocationCountry(name)) == country {
+						locations = append(locations, graphLocation(name))
+					}
+				}
+				return locations, nil
+			},
+		},
+	}}
+
+	schema, err := graphql.NewSchema(query, artist, member, concert, location)
+	if err != nil {
+		panic(err)
+	}
+	schema.MaxDepth = graphQLMaxDepth
+	schema.MaxFields = graphQLMaxFields
+	return schema
+}
+
+func resolveArtist(id int) (interface{}, error) {
+	a, err := api.GetArtistByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return *a, nil
+}
+
+// locationArtists retourne les artistes distincts d'une liste de concerts,
+// dans l'ordre de leur premier concert
+func locationArtists(concerts []models.Concert) []models.Artist {
+	seen := make(map[int]bool)
+	var artists []models.Artist
+	for _, concert := range concerts {
+		if seen[concert.ArtistID] {
+			continue
+		}
+		seen[concert.ArtistID] = true
+		if artist, err := api.GetArtistByID(concert.ArtistID); err == nil {
+			artists = append(artists, *artist)
+		}
+	}
+	return artists
+}
+
+// =======================
+// GRAPHQL
+// =======================
+
+// GraphQLHandler sert /graphql : POST {"query", "variables", "operationName"}
+// en JSON, ou GET ?query=&variables=&operationName=
+func GraphQLHandler(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				writeError(w, r, newError(http.StatusBadRequest, "error.variablesNotObject"))
+				return
+			}
+		}
+
+	case http.MethodPost:
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			writeError(w, r, newError(http.StatusUnsupportedMediaType, "error.expectedJSON"))
+			return
+		}
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, graphQLMaxBody))
+		if err := dec.Decode(&req); err != nil {
+			writeError(w, r, newError(http.StatusBadRequest, "error.invalidJSON"))
+			return
+		}
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		writeError(w, r, newError(http.StatusBadRequest, "error.missingQuery"))
+		return
+	}
+
+	result := graphQLSchema.Execute(r.Context(), req)
+
+	// Sans data, la requête n'a pas été exécutée (syntaxe ou validation)
+	status := http.StatusOK
+	if result.Data == nil {
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, result)
+}
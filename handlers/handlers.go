@@ -1,22 +1,86 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
+	"io/fs"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"api-groupie-tracker/api"
+	"api-groupie-tracker/i18n"
 	"api-groupie-tracker/models"
 	"api-groupie-tracker/utils"
 )
 
-var templates *template.Template
+var (
+	// Templates analysés, un jeu par langue
+	templates map[string]*template.Template
 
-func init() {
-	templates = template.Must(template.ParseGlob("templates/*.html"))
+	// En mode développement, les templates sont relus à chaque rendu
+	templateFS     fs.FS
+	reloadTemplate bool
+
+	// Empreinte des templates chargés, qui entre dans les ETags
+	templateVersion string
+)
+
+// UseTemplates charge les templates *.html de fsys. Avec reload, ils sont
+// de plus relus à chaque rendu, pour voir les modifications sans redémarrer.
+func UseTemplates(fsys fs.FS, reload bool) error {
+	parsed := make(map[string]*template.Template, len(i18n.Languages))
+	for _, lang := range i18n.Languages {
+		t, err := parseTemplates(fsys, lang)
+		if err != nil {
+			return err
+		}
+		parsed[lang] = t
+	}
+	version, err := hashFiles(fsys, "*.html")
+	if err != nil {
+		return err
+	}
+	templates, templateFS, reloadTemplate, templateVersion = parsed, fsys, reload, version
+	return nil
+}
+
+// hashFiles résume le contenu des fichiers de fsys correspondant à pattern
+func hashFiles(fsys fs.FS, pattern string) (string, error) {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s %d\n", name, len(data))
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:8], nil
+}
+
+// executeTemplate rend le template name avec data, dans la langue lang
+func executeTemplate(w io.Writer, lang, name string, data interface{}) error {
+	t := templates[lang]
+	if reloadTemplate {
+		var err error
+		if t, err = parseTemplates(templateFS, lang); err != nil {
+			return err
+		}
+	}
+	if t == nil {
+		return fmt.Errorf("templates non chargés (%s)", lang)
+	}
+	return t.ExecuteTemplate(w, name, data)
 }
 
 /*
@@ -36,6 +100,27 @@ type PageData struct {
 
 	Query    string
 	Filtered bool
+
+	// ExportQuery reprend les paramètres de la page (q ou filtres) dans les
+	// liens d'export, pour que le fichier corresponde aux résultats affichés
+	ExportQuery template.URL
+}
+
+/*
+	ArtistPageData
+	➡️ STRUCT pour artist.html
+	➡️ embarque FullArtist : les champs .Name, .Members... restent accessibles
+*/
+type ArtistPageData struct {
+	models.FullArtist
+	Overlaps []models.Overlap
+	Similar  []models.SimilarArtist
+
+	Meta      PageMeta
+	JSONLD    template.JS
+	MapData   template.JS
+	StaticMap template.HTML
+	MapsKey   string
 }
 
 // =======================
@@ -74,9 +159,7 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 		Filtered: false,
 	}
 
-	if err := templates.ExecuteTemplate(w, "index.html", data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	render(w, r, "index.html", data)
 }
 
 // =======================
@@ -84,12 +167,23 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 // =======================
 func ArtistHandler(w http.ResponseWriter, r *http.Request) {
 	idStr := strings.TrimPrefix(r.URL.Path, "/artist/")
+	idStr, resource, _ := strings.Cut(idStr, "/")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		ErrorHandler(w, r, http.StatusBadRequest)
 		return
 	}
 
+	switch resource {
+	case "":
+	case "concerts.ics":
+		ArtistICSHandler(w, r, id)
+		return
+	default:
+		ErrorHandler(w, r, http.StatusNotFound)
+		return
+	}
+
 	fullArtist, err := api.GetFullArtistByID(id)
 	if err != nil {
 		ErrorHandler(w, r, http.StatusNotFound)
@@ -98,9 +192,20 @@ func ArtistHandler(w http.ResponseWriter, r *http.Request) {
 
 	fullArtist.FirstAlbumYear = utils.ExtractYear(fullArtist.FirstAlbum)
 
-	if err := templates.ExecuteTemplate(w, "artist.html", fullArtist); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	lang := requestLang(r)
+	mapData := newMapResponse(fullArtist.Artist)
+	data := ArtistPageData{
+		FullArtist: *fullArtist,
+		Overlaps:   api.GetOverlaps(id),
+		Similar:    localizeSimilar(lang, api.GetSimilar(id)),
+		Meta:       artistMeta(r, lang, *fullArtist),
+		JSONLD:     artistJSONLD(r, *fullArtist),
+		MapData:    artistMapJSON(mapData),
+		StaticMap:  artistStaticMap(lang, mapData),
+		MapsKey:    googleMapsKey,
 	}
+
+	render(w, r, "artist.html", data)
 }
 
 // =======================
@@ -112,6 +217,73 @@ func FilterHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	criteria := parseFilterCriteria(r)
+
+	artists := api.GetAllArtists()
+	filtered := filterArtists(artists, criteria)
+
+	minCreation, maxCreation, minAlbum, maxAlbum := utils.GetYearRange(artists)
+	minMembers, maxMembers := utils.GetMembersRange(artists)
+
+	data := PageData{
+		Artists:      filtered,
+		MinCreation:  minCreation,
+		MaxCreation:  maxCreation,
+		MinAlbum:     minAlbum,
+		MaxAlbum:     maxAlbum,
+		MinMembers:   minMembers,
+		MaxMembers:   maxMembers,
+		AllLocations: utils.GetUniqueLocations(artists, api.Relations.Index),
+
+		Query:    "",
+		Filtered: true,
+
+		// r.Form contient aussi les champs d'un formulaire POST
+		ExportQuery: template.URL(r.Form.Encode()),
+	}
+
+	render(w, r, "index.html", data)
+}
+
+// =======================
+// SEARCH
+// =======================
+func SearchHandler(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	searchQueries.Inc("page")
+
+	artists := api.GetAllArtists()
+	query = strings.ToLower(query)
+	results := searchArtists(artists, query)
+
+	minCreation, maxCreation, minAlbum, maxAlbum := utils.GetYearRange(artists)
+	minMembers, maxMembers := utils.GetMembersRange(artists)
+
+	data := PageData{
+		Artists:      results,
+		MinCreation:  minCreation,
+		MaxCreation:  maxCreation,
+		MinAlbum:     minAlbum,
+		MaxAlbum:     maxAlbum,
+		MinMembers:   minMembers,
+		MaxMembers:   maxMembers,
+		AllLocations: utils.GetUniqueLocations(artists, api.Relations.Index),
+
+		Query:    query,
+		Filtered: true,
+
+		ExportQuery: template.URL(r.URL.Query().Encode()),
+	}
+
+	render(w, r, "index.html", data)
+}
+
+// parseFilterCriteria lit les critères du formulaire de filtres
+func parseFilterCriteria(r *http.Request) models.FilterCriteria {
 	criteria := models.FilterCriteria{}
 	r.ParseForm()
 
@@ -138,7 +310,11 @@ func FilterHandler(w http.ResponseWriter, r *http.Request) {
 
 	criteria.Locations = r.Form["locations"]
 
-	artists := api.GetAllArtists()
+	return criteria
+}
+
+// filterArtists applique les critères, y compris le filtre par locations
+func filterArtists(artists []models.Artist, criteria models.FilterCriteria) []models.FullArtist {
 	filtered := utils.FilterArtists(artists, criteria)
 
 	if len(criteria.Locations) > 0 {
@@ -158,43 +334,14 @@ func FilterHandler(w http.ResponseWriter, r *http.Request) {
 		filtered = final
 	}
 
-	minCreation, maxCreation, minAlbum, maxAlbum := utils.GetYearRange(artists)
-	minMembers, maxMembers := utils.GetMembersRange(artists)
-
-	data := PageData{
-		Artists:      filtered,
-		MinCreation:  minCreation,
-		MaxCreation:  maxCreation,
-		MinAlbum:     minAlbum,
-		MaxAlbum:     maxAlbum,
-		MinMembers:   minMembers,
-		MaxMembers:   maxMembers,
-		AllLocations: utils.GetUniqueLocations(artists, api.Relations.Index),
-
-		Query:    "",
-		Filtered: true,
-	}
-
-	if err := templates.ExecuteTemplate(w, "index.html", data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	return filtered
 }
 
-// =======================
-// SEARCH
-// =======================
-func SearchHandler(w http.ResponseWriter, r *http.Request) {
-	query := strings.TrimSpace(r.URL.Query().Get("q"))
-	if query == "" {
-		http.Redirect(w, r, "/", http.StatusSeeOther)
-		return
-	}
-
-	artists := api.GetAllArtists()
+// searchArtists retourne les artistes dont le nom, un membre, une date ou
+// une location contient la requête (déjà en minuscules)
+func searchArtists(artists []models.Artist, query string) []models.FullArtist {
 	var results []models.FullArtist
 
-	query = strings.ToLower(query)
-
 	for _, artist := range artists {
 		match := false
 
@@ -225,26 +372,7 @@ func SearchHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	minCreation, maxCreation, minAlbum, maxAlbum := utils.GetYearRange(artists)
-	minMembers, maxMembers := utils.GetMembersRange(artists)
-
-	data := PageData{
-		Artists:      results,
-		MinCreation:  minCreation,
-		MaxCreation:  maxCreation,
-		MinAlbum:     minAlbum,
-		MaxAlbum:     maxAlbum,
-		MinMembers:   minMembers,
-		MaxMembers:   maxMembers,
-		AllLocations: utils.GetUniqueLocations(artists, api.Relations.Index),
-
-		Query:    query,
-		Filtered: true,
-	}
-
-	if err := templates.ExecuteTemplate(w, "index.html", data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	return results
 }
 
 // =======================
@@ -252,35 +380,48 @@ func SearchHandler(w http.ResponseWriter, r *http.Request) {
 // =======================
 func SuggestionsHandler(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
+	if strings.TrimSpace(query) != "" {
+		searchQueries.Inc("suggestions")
+	}
 
 	artists := api.GetAllArtists()
 	suggestions := utils.SearchArtists(artists, query)
 
+	lang := requestLang(r)
+	for i := range suggestions {
+		suggestions[i].Label = i18n.T(lang, "suggestion."+suggestions[i].Type)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(suggestions)
 }
 
-// =======================
-// ERROR
-// =======================
-func ErrorHandler(w http.ResponseWriter, r *http.Request, status int) {
-	w.WriteHeader(status)
+// Origine publique configurée du site, sans / final. Vide, elle est déduite
+// de la requête.
+var publicURL string
 
-	data := struct {
-		Code    int
-		Message string
-	}{
-		Code:    status,
-		Message: http.StatusText(status),
-	}
+// SetPublicURL fixe l'origine utilisée pour les liens absolus
+func SetPublicURL(u string) {
+	publicURL = strings.TrimSuffix(u, "/")
+}
 
-	if status == http.StatusNotFound {
-		data.Message = "La page que vous recherchez n'existe pas."
-	} else if status == http.StatusInternalServerError {
-		data.Message = "Une erreur interne du serveur s'est produite."
+// baseURL retourne l'origine absolue du site : l'URL publique configurée,
+// sinon le schéma et l'hôte de la requête. X-Forwarded-Proto n'est lu que
+// derrière un proxy de confiance (voir SetTrustedProxyHeader).
+func baseURL(r *http.Request) string {
+	if publicURL != "" {
+		return publicURL
 	}
-
-	if err := templates.ExecuteTemplate(w, "error.html", data); err != nil {
-		http.Error(w, fmt.Sprintf("Error %d", status), status)
+	scheme := "http"
+	if r.TLS != nil || (trustedProxyHeader != "" && r.Header.Get("X-Forwarded-Proto") == "https") {
+		scheme = "https"
 	}
+	return scheme + "://" + r.Host
+}
+
+// writeJSON encode une réponse JSON avec le statut donné
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
 }
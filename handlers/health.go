@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/models"
+)
+
+var (
+	startedAt = time.Now()
+
+	// Âge maximal des données pour /readyz, 0 pour ne pas le vérifier
+	maxDataAge time.Duration
+)
+
+// SetMaxDataAge fixe l'âge au-delà duquel les données sont jugées périmées
+func SetMaxDataAge(d time.Duration) {
+	maxDataAge = d
+}
+
+// HealthResponse est la réponse de /healthz
+type HealthResponse struct {
+	Status        string  `json:"status"`
+	UptimeSeconds float64 `json:"uptimeSeconds"`
+}
+
+// ReadinessCheck est le résultat d'une des vérifications de /readyz
+type ReadinessCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+// ReadinessResponse est la réponse de /readyz : le verdict, chaque
+// vérification et l'état détaillé des données
+type ReadinessResponse struct {
+	Status string           `json:"status"` // "ready" ou "unavailable"
+	Checks []ReadinessCheck `json:"checks"`
+
+	Data               models.DataState `json:"data"`
+	DataAgeSeconds     float64          `json:"dataAgeSeconds"`
+	SnapshotAgeSeconds float64          `json:"snapshotAgeSeconds,omitempty"`
+}
+
+// =======================
+// HEALTH
+// =======================
+
+// HealthHandler répond tant que le processus tourne, quel que soit l'état
+// des données
+func HealthHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, HealthResponse{
+		Status:        "ok",
+		UptimeSeconds: time.Since(startedAt).Seconds(),
+	})
+}
+
+// ReadyHandler répond 200 si le serveur peut servir des pages : données
+// chargées, assez récentes et templates analysés. Sinon 503.
+func ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	state := api.GetDataState()
+	now := time.Now()
+
+	response := ReadinessResponse{Data: state}
+	if !state.LastRefresh.IsZero() {
+		response.DataAgeSeconds = now.Sub(state.LastRefresh).Seconds()
+	}
+	if !state.SnapshotSavedAt.IsZero() {
+		response.SnapshotAgeSeconds = now.Sub(state.SnapshotSavedAt).Seconds()
+	}
+
+	response.Checks = []ReadinessCheck{
+		dataCheck(state),
+		freshnessCheck(state, now),
+		templatesCheck(),
+	}
+
+	status, code := "ready", http.StatusOK
+	for _, check := range response.Checks {
+		if !check.OK {
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+	}
+	response.Status = status
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, code, response)
+}
+
+func dataCheck(state models.DataState) ReadinessCheck {
+	check := ReadinessCheck{Name: "data", OK: state.Artists > 0}
+	if check.OK {
+		check.Detail = fmt.Sprintf("%d artistes, %d concerts (source : %s)", state.Artists, state.Concerts, state.Source)
+	} else {
+		check.Detail = "aucune donnée chargée"
+	}
+	return check
+}
+
+func freshnessCheck(state models.DataState, now time.Time) ReadinessCheck {
+	check := ReadinessCheck{Name: "freshness", OK: true}
+	switch {
+	case state.LastRefresh.IsZero():
+		check.OK, check.Detail = false, "aucun chargement réussi"
+	case maxDataAge == 0:
+		check.Detail = "âge non vérifié"
+	default:
+		age := now.Sub(state.LastRefresh).Round(time.Second)
+		check.OK = age <= maxDataAge
+		check.Detail = fmt.Sprintf("données âgées de %s (maximum %s)", age, maxDataAge)
+	}
+	return check
+}
+
+// templatesCheck vérifie que les templates sont analysés ; en mode
+// développement, qu'ils s'analysent encore depuis le disque
+func templatesCheck() ReadinessCheck {
+	check := ReadinessCheck{Name: "templates", OK: templates != nil}
+	if reloadTemplate {
+		if _, err := parseTemplates(templateFS, i18n.Default); err != nil {
+			check.OK, check.Detail = false, err.Error()
+			return check
+		}
+	}
+	if check.OK {
+		check.Detail = fmt.Sprintf("%d templates", len(templates[i18n.Default].Templates()))
+	} else {
+		check.Detail = "templates non chargés"
+	}
+	return check
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"api-groupie-tracker/api"
+)
+
+// loadTestData charge un artiste, un concert et un template index.html,
+// comme si la sauvegarde datée de savedAt avait été relue au démarrage
+func loadTestData(t *testing.T, savedAt time.Time) {
+	t.Helper()
+	snapshot := filepath.Join(t.TempDir(), "data.json")
+	content := fmt.Sprintf(`{"savedAt": %q, "artists": [{"id": 1, "name": "Queen"}],
+		"relations": {"index": [{"id": 1, "datesLocations": {"london-uk": ["01-09-2019"]}}]}}`,
+		savedAt.UTC().Format(time.RFC3339))
+	if err := os.WriteFile(snapshot, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.LoadSnapshot(snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UseTemplates(fstest.MapFS{"index.html": {Data: []byte("ok")}}, false); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { templates = nil })
+}
+
+func readiness(t *testing.T) (int, ReadinessResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	ReadyHandler(rec, httptest.NewRequest("GET", "/readyz", nil))
+
+	var response ReadinessResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid /readyz JSON: %v", err)
+	}
+	return rec.Code, response
+}
+
+func TestReadiness(t *testing.T) {
+	rec := httptest.NewRecorder()
+	HealthHandler(rec, httptest.NewRequest("GET", "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /healthz = %d; expected 200", rec.Code)
+	}
+
+	// Ni données ni templates : le serveur n'est pas prêt
+	if code, response := readiness(t); code != http.StatusServiceUnavailable || response.Status != "unavailable" {
+		t.Fatalf("GET /readyz before loading = %d %q; expected 503", code, response.Status)
+	}
+
+	loadTestData(t, time.Now().Add(-2*time.Hour))
+
+	SetMaxDataAge(3 * time.Hour)
+	code, response := readiness(t)
+	if code != http.StatusOK || response.Status != "ready" {
+		t.Fatalf("GET /readyz = %d %+v; expected 200", code, response.Checks)
+	}
+	if response.Data.Source != api.SourceSnapshot || response.Data.Artists != 1 || response.Data.Concerts != 1 {
+		t.Errorf("data = %+v; expected 1 artist and 1 concert from the snapshot", response.Data)
+	}
+	if len(response.Data.Upstream) != 4 {
+		t.Errorf("upstream = %+v; expected the 4 API endpoints", response.Data.Upstream)
+	}
+	if age := response.DataAgeSeconds; age < 7200 || age > 7260 {
+		t.Errorf("dataAgeSeconds = %v; expected about 2h", age)
+	}
+
+	// Des données plus vieilles que le maximum rendent le serveur indisponible
+	SetMaxDataAge(time.Hour)
+	t.Cleanup(func() { SetMaxDataAge(0) })
+	code, response = readiness(t)
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("GET /readyz with stale data = %d; expected 503", code)
+	}
+	for _, check := range response.Checks {
+		if check.OK != (check.Name != "freshness") {
+			t.Errorf("check %s ok = %v (%s)", check.Name, check.OK, check.Detail)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/models"
+	"api-groupie-tracker/utils"
+)
+
+// =======================
+// ICALENDAR
+// =======================
+
+// ArtistICSHandler sert /artist/{id}/concerts.ics
+func ArtistICSHandler(w http.ResponseWriter, r *http.Request, id int) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	artist, err := api.GetArtistByID(id)
+	if err != nil {
+		ErrorHandler(w, r, http.StatusNotFound)
+		return
+	}
+
+	concerts := utils.FilterConcerts(api.GetConcerts(), models.ConcertCriteria{ArtistIDs: []int{id}})
+	writeICS(w, r, artist.Name, fmt.Sprintf("artist-%d.ics", id), concerts)
+}
+
+// ConcertsICSHandler sert /api/v1/concerts.ics avec les filtres de parseConcertCriteria
+func ConcertsICSHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	criteria, err := parseConcertCriteria(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	concerts := utils.FilterConcerts(api.GetConcerts(), criteria)
+	writeICS(w, r, "Groupie Tracker", "concerts.ics", concerts)
+}
+
+func writeICS(w http.ResponseWriter, r *http.Request, name, filename string, concerts []models.Concert) {
+	var buf bytes.Buffer
+	if err := utils.WriteICS(&buf, name, concerts, api.GetLastRefresh()); err != nil {
+		writeError(w, r, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/models"
+	"api-groupie-tracker/utils"
+)
+
+// Cookie mémorisant la langue choisie avec ?lang=
+const (
+	langCookie       = "lang"
+	langCookieMaxAge = 365 * 24 * time.Hour
+)
+
+type langKey struct{}
+
+// Language détermine la langue de la réponse : ?lang= (mémorisé dans un
+// cookie), sinon le cookie, sinon l'en-tête Accept-Language. La langue est
+// placée dans le contexte et annoncée par Content-Language.
+func Language(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang, chosen := resolveLang(r)
+		if chosen {
+			http.SetCookie(w, &http.Cookie{
+				Name:     langCookie,
+				Value:    lang,
+				Path:     "/",
+				MaxAge:   int(langCookieMaxAge.Seconds()),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		// Les fichiers statiques ne dépendent pas de la langue
+		if !strings.HasPrefix(r.URL.Path, "/static/") {
+			w.Header().Set("Content-Language", lang)
+			w.Header().Add("Vary", "Accept-Language, Cookie")
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), langKey{}, lang)))
+	})
+}
+
+// resolveLang détermine la langue de r ; chosen indique qu'elle vient de
+// ?lang= et doit être mémorisée
+func resolveLang(r *http.Request) (lang string, chosen bool) {
+	if v := strings.ToLower(r.URL.Query().Get("lang")); i18n.Supported(v) {
+		return v, true
+	}
+	if c, err := r.Cookie(langCookie); err == nil && i18n.Supported(c.Value) {
+		return c.Value, false
+	}
+	return i18n.Negotiate(r.Header.Get("Accept-Language")), false
+}
+
+// requestLang retourne la langue de la requête, la langue par défaut hors
+// middleware
+func requestLang(r *http.Request) string {
+	return contextLang(r.Context())
+}
+
+// contextLang retourne la langue placée dans ctx par Language (résolveurs
+// GraphQL par exemple)
+func contextLang(ctx context.Context) string {
+	if lang, ok := ctx.Value(langKey{}).(string); ok {
+		return lang
+	}
+	return i18n.Default
+}
+
+// parseTemplates analyse les templates *.html de fsys pour la langue lang
+func parseTemplates(fsys fs.FS, lang string) (*template.Template, error) {
+	return template.New("").Funcs(templateFuncs(lang)).ParseFS(fsys, "*.html")
+}
+
+// templateFuncs retourne les fonctions de traduction des templates, liées
+// à la langue lang :
+//
+//	{{ T "nav.back" }}, {{ N "card.members" (len .Members) }}, {{ month .Month }}
+//
+// Les fonctions de date acceptent un time.Time, un models.LocalDate ou une
+// date brute de l'API ("*23-08-2019") :
+//
+//	{{ date . }}      21 août 2019
+//	{{ shortDate . }} 21/08/2019
+//	{{ relative . }}  il y a 5 ans
+//	{{ isoDate . }}   2019-08-21, pour l'attribut datetime de <time>
+func templateFuncs(lang string) template.FuncMap {
+	return template.FuncMap{
+		"T": func(key string, args ...interface{}) string {
+			return i18n.T(lang, key, args...)
+		},
+		"N": func(key string, n int) string {
+			return i18n.N(lang, key, n)
+		},
+		"lang": func() string {
+			return lang
+		},
+		"languages": func() []string {
+			return i18n.Languages
+		},
+		"month": func(m time.Month) string {
+			return i18n.MonthName(lang, m)
+		},
+		"date": dateFunc(func(t time.Time) string {
+			return i18n.FormatDate(lang, t)
+		}),
+		"shortDate": dateFunc(func(t time.Time) string {
+			return i18n.FormatShortDate(lang, t)
+		}),
+		"relative": dateFunc(func(t time.Time) string {
+			return i18n.FormatRelative(lang, t, time.Now())
+		}),
+		"isoDate": dateFunc(func(t time.Time) string {
+			return t.Format(models.ISODateLayout)
+		}),
+	}
+}
+
+// dateFunc adapte format en fonction de template. Une date brute illisible
+// est affichée telle quelle plutôt que de faire échouer le rendu.
+func dateFunc(format func(time.Time) string) func(interface{}) (string, error) {
+	return func(v interface{}) (string, error) {
+		switch d := v.(type) {
+		case time.Time:
+			return format(d), nil
+		case models.LocalDate:
+			return format(d.Time), nil
+		case string:
+			t, err := utils.ParseDate(d)
+			if err != nil {
+				return d, nil
+			}
+			return format(t), nil
+		}
+		return "", fmt.Errorf("date : type %T non pris en charge", v)
+	}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/models"
+)
+
+func TestLanguage(t *testing.T) {
+	var lang string
+	h := Language(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang = requestLang(r)
+	}))
+
+	tests := []struct {
+		name           string
+		target         string
+		cookie         string
+		acceptLanguage string
+		expected       string
+		setsCookie     bool
+	}{
+		{"default", "/", "", "", "fr", false},
+		{"accept-language", "/", "", "en-US,en;q=0.9,fr;q=0.8", "en", false},
+		{"cookie", "/", "en", "fr", "en", false},
+		{"invalid cookie", "/", "de", "en", "en", false},
+		{"query", "/stats?lang=en", "fr", "fr", "en", true},
+		{"invalid query", "/stats?lang=xx", "", "en", "en", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.target, nil)
+		if tt.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: langCookie, Value: tt.cookie})
+		}
+		if tt.acceptLanguage != "" {
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if lang != tt.expected || rec.Header().Get("Content-Language") != tt.expected {
+			t.Errorf("%s: language = %q (Content-Language %q); expected %q",
+				tt.name, lang, rec.Header().Get("Content-Language"), tt.expected)
+		}
+		cookies := rec.Result().Cookies()
+		if tt.setsCookie != (len(cookies) == 1 && cookies[0].Name == langCookie && cookies[0].Value == tt.expected) {
+			t.Errorf("%s: cookies = %v", tt.name, cookies)
+		}
+	}
+}
+
+func TestLocalizedError(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/artists/999", nil)
+	req.Header.Set("Accept-Language", "en")
+	rec := httptest.NewRecorder()
+	Language(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, newError(http.StatusNotFound, "error.artistNotFound"))
+	})).ServeHTTP(rec, req)
+
+	var problem Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Detail != "artist not found" {
+		t.Errorf("detail = %q; expected the English message", problem.Detail)
+	}
+}
+
+func TestLocalizedValidationErrors(t *testing.T) {
+	tests := []struct {
+		method, target, body string
+		expected             string
+	}{
+		{"GET", "/api/v1/concerts/nearby?lat=100&lon=0", "", "lat must be a number between -90 and 90"},
+		{"GET", "/api/v1/concerts/nearby?lat=0&lon=0&radius_km=-1", "", "radius_km must be between 0 and 20038"},
+		{"GET", "/api/v1/concerts.ics?from=demain", "", "from must use the YYYY-MM-DD format"},
+		{"POST", "/graphql", `{"query": "{ concerts(to: \"demain\") { date } }"}`, "to must use the YYYY-MM-DD format"},
+	}
+	routes := map[string]http.HandlerFunc{
+		"/api/v1/concerts/nearby": NearbyConcertsHandler,
+		"/api/v1/concerts.ics":    ConcertsICSHandler,
+		"/graphql":                GraphQLHandler,
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		req.Header.Set("Accept-Language", "en")
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		path, _, _ := strings.Cut(tt.target, "?")
+		Language(routes[path]).ServeHTTP(rec, req)
+
+		if !strings.Contains(rec.Body.String(), tt.expected) {
+			t.Errorf("%s %s = %d %s; expected %q", tt.method, tt.target, rec.Code, rec.Body, tt.expected)
+		}
+	}
+}
+
+// Chaque clé "error.*" passée à newError ou i18n.T par les handlers doit
+// exister dans le catalogue
+func TestErrorKeysInCatalogue(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := regexp.MustCompile(`(?:newError\([^,]+|i18n\.T\([^,]+), "(error\.[A-Za-z]+)"`)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range key.FindAllStringSubmatch(string(content), -1) {
+			for _, lang := range i18n.Languages {
+				if i18n.T(lang, m[1]) == m[1] {
+					t.Errorf("%s: %q missing from the %s catalogue", file, m[1], lang)
+				}
+			}
+		}
+	}
+}
+
+func TestLocalizeStats(t *testing.T) {
+	shared := models.Stats{
+		ArtistsPerDecade: []models.StatBar{{Key: "stats.decade", Args: []interface{}{1970}, Value: 2}},
+		BusiestCities:    []models.StatBar{{Label: "lyon-france", Value: 3}},
+		CreationToAlbum:  []models.StatBar{{Key: "stats.gap.sixPlus", Value: 1}},
+	}
+	stats := localizeStats("en", shared)
+
+	if got := stats.ArtistsPerDecade[0].Label; got != "1970s" {
+		t.Errorf("decade label = %q; expected 1970s", got)
+	}
+	if got := stats.CreationToAlbum[0].Label; got != "6 years or more" {
+		t.Errorf("gap label = %q; expected the English bucket", got)
+	}
+	if got := stats.BusiestCities[0].Label; got != "lyon-france" {
+		t.Errorf("city label = %q; expected it unchanged", got)
+	}
+	if shared.ArtistsPerDecade[0].Label != "" {
+		t.Error("localizeStats modified the shared statistics")
+	}
+}
+
+func TestDateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(templateFuncs("en")).Parse(
+		`{{ date . }}|{{ shortDate . }}|{{ isoDate . }}`))
+
+	album := time.Date(1973, time.December, 14, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input    interface{}
+		expected string
+	}{
+		{"*23-08-2019", "August 23, 2019|08/23/2019|2019-08-23"},
+		{album, "December 14, 1973|12/14/1973|1973-12-14"},
+		{models.LocalDate{Time: album}, "December 14, 1973|12/14/1973|1973-12-14"},
+		{"bientôt", "bientôt|bientôt|bientôt"},
+	}
+	for _, tt := range tests {
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, tt.input); err != nil {
+			t.Errorf("%v: %v", tt.input, err)
+			continue
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("%v = %q; expected %q", tt.input, buf.String(), tt.expected)
+		}
+	}
+
+	if err := tmpl.Execute(io.Discard, 42); err == nil {
+		t.Error("expected an error for an unsupported type")
+	}
+}
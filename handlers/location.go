@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/models"
+)
+
+/*
+	LocationPageData
+	➡️ STRUCT pour location.html
+*/
+type LocationPageData struct {
+	Name     string
+	Known    bool
+	Lat      float64
+	Lon      float64
+	Concerts []models.Concert
+	Artists  []models.Artist
+}
+
+// =======================
+// LOCATION DETAILS
+// =======================
+func LocationHandler(w http.ResponseWriter, r *http.Request) {
+	// r.URL.Path est déjà décodé : "%25" y est devenu "%"
+	name := strings.TrimPrefix(r.URL.Path, "/location/")
+	if name == "" || strings.Contains(name, "/") {
+		ErrorHandler(w, r, http.StatusBadRequest)
+		return
+	}
+
+	concerts := api.GetLocationConcerts(name)
+	if len(concerts) == 0 {
+		ErrorHandler(w, r, http.StatusNotFound)
+		return
+	}
+
+	data := LocationPageData{
+		Name:     concerts[0].Location,
+		Concerts: concerts,
+	}
+
+	if p, ok := api.GetLocationPosition(data.Name); ok {
+		data.Known, data.Lat, data.Lon = true, p.Lat, p.Lon
+	}
+
+	data.Artists = locationArtists(concerts)
+
+	render(w, r, "location.html", data)
+}
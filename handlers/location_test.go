@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Le chemin n'est décodé qu'une fois : "%25" désigne un "%" littéral
+func TestLocationHandlerDecoding(t *testing.T) {
+	loadArtistLocations(t, []string{"club_100%-uk", "club_25-uk"})
+
+	tests := []struct {
+		target   string
+		status   int
+		expected string
+	}{
+		{"/location/club_100%25-uk", http.StatusOK, "club_100%-uk"},
+		{"/location/club_%2525-uk", http.StatusNotFound, ""},
+		{"/location/a%2Fb", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		LocationHandler(rec, httptest.NewRequest("GET", tt.target, nil))
+		if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.expected) {
+			t.Errorf("GET %s = %d; expected %d with %q", tt.target, rec.Code, tt.status, tt.expected)
+		}
+	}
+}
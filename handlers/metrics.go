@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"api-groupie-tracker/metrics"
+)
+
+// Métriques des requêtes, par motif de route (et non par chemin, pour que
+// /artist/1 et /artist/2 partagent la même série)
+var (
+	httpRequests = metrics.NewCounter("groupie_http_requests_total",
+		"Requêtes HTTP traitées.", "route", "method", "status")
+	httpDuration = metrics.NewHistogram("groupie_http_request_duration_seconds",
+		"Durée de traitement des requêtes HTTP.", metrics.DefaultBuckets, "route")
+	searchQueries = metrics.NewCounter("groupie_search_queries_total",
+		"Recherches effectuées, par origine (page ou suggestions).", "source")
+)
+
+// Metrics mesure chaque requête sous le motif de mux qui la sert
+func Metrics(mux *http.ServeMux) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, route := mux.Handler(r)
+			if route == "" {
+				route = "other"
+			}
+
+			start := time.Now()
+			rec := &responseRecorder{ResponseWriter: w}
+			done := false
+			defer func() {
+				status := rec.status
+				switch {
+				case status == 0 && !done:
+					// Panique avant toute réponse : Recover répondra 500
+					status = http.StatusInternalServerError
+				case status == 0:
+					status = http.StatusOK
+				}
+				httpRequests.Inc(route, r.Method, strconv.Itoa(status))
+				httpDuration.Observe(time.Since(start).Seconds(), route)
+			}()
+			next.ServeHTTP(rec, r)
+			done = true
+		})
+	}
+}
+
+// =======================
+// METRICS
+// =======================
+
+// MetricsHandler expose les métriques au format texte de Prometheus
+func MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.Default.WriteText(w)
+}
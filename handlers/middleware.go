@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// Middleware enveloppe un handler, par exemple pour journaliser la requête
+type Middleware func(http.Handler) http.Handler
+
+// Chain applique les middlewares à h : le premier de la liste est le plus
+// extérieur et voit donc la requête en premier
+func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// En-tête transportant l'identifiant de requête, repris du client ou du
+// proxy s'il est fourni
+const requestIDHeader = "X-Request-ID"
+
+// Longueur maximale d'un identifiant fourni par le client
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID attribue un identifiant à chaque requête, le renvoie dans
+// l'en-tête X-Request-ID et le place dans le contexte
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// RequestIDFrom retourne l'identifiant de la requête, ou "" hors middleware
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Logger retourne le logger par défaut, annoté de l'identifiant de requête
+func Logger(ctx context.Context) *slog.Logger {
+	if id := RequestIDFrom(ctx); id != "" {
+		return slog.Default().With("request_id", id)
+	}
+	return slog.Default()
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// validRequestID n'accepte que des caractères imprimables sans espace, pour
+// qu'un identifiant fourni ne puisse pas altérer les journaux
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+	responseRecorder
+	➡️ mémorise le statut et la taille de la réponse écrite
+*/
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(b)
+	rec.size += n
+	return n, err
+}
+
+// Unwrap donne accès au ResponseWriter d'origine (http.ResponseController)
+func (rec *responseRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// AccessLog journalise chaque requête une fois la réponse écrite
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		Logger(r.Context()).Info("requête",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", status,
+			"size", rec.size,
+			"duration", time.Since(start),
+		)
+	})
+}
+
+// Recover transforme une panique dans un handler ou un middleware placé
+// après lui en page d'erreur 500 et journalise la pile d'appels. Si la
+// réponse a déjà commencé, la connexion est simplement interrompue.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &responseRecorder{ResponseWriter: w}
+		header := w.Header().Clone()
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(v)
+			}
+
+			Logger(r.Context()).Error("panique dans un handler",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"panic", fmt.Sprint(v),
+				"stack", string(debug.Stack()),
+			)
+			if rec.status != 0 {
+				panic(http.ErrAbortHandler)
+			}
+
+			// Les en-têtes posés plus bas (cache, compression, type) ne
+			// s'appliquent pas à la page d'erreur
+			h := w.Header()
+			for name := range h {
+				delete(h, name)
+			}
+			for name, values := range header {
+				h[name] = values
+			}
+
+			// La panique a pu survenir avant le middleware Language
+			if _, ok := r.Context().Value(langKey{}).(string); !ok {
+				lang, _ := resolveLang(r)
+				r = r.WithContext(context.WithValue(r.Context(), langKey{}, lang))
+			}
+			writeError(w, r, newError(http.StatusInternalServerError, ""))
+		}()
+		next.ServeHTTP(rec, r)
+	})
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"api-groupie-tracker/metrics"
+)
+
+// captureLogs redirige le logger par défaut vers un tampon le temps du test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestRecoverRendersProblem(t *testing.T) {
+	logs := captureLogs(t)
+
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("template cassé")
+	}), RequestID, AccessLog, Recover)
+
+	req := httptest.NewRequest("GET", "/api/v1/concerts", nil)
+	req.Header.Set("X-Request-ID", "abc-123")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d; expected 500", rec.Code)
+	}
+	if got := rec.Header().Get("X-Request-ID"); got != "abc-123" {
+		t.Errorf("X-Request-ID = %q; expected the client's ID", got)
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil || problem.Status != 500 {
+		t.Errorf("body = %s; expected a 500 problem", rec.Body)
+	}
+	if strings.Contains(rec.Body.String(), "template cassé") {
+		t.Error("the panic value leaked into the response")
+	}
+
+	// Une ligne pour la panique, une pour l'accès, toutes deux avec l'ID
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines; expected 2:\n%s", len(lines), logs)
+	}
+	for _, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if entry["request_id"] != "abc-123" {
+			t.Errorf("log line %s has no request_id", line)
+		}
+	}
+	if !strings.Contains(lines[0], "template cassé") || !strings.Contains(lines[1], `"status":500`) {
+		t.Errorf("unexpected logs:\n%s", logs)
+	}
+}
+
+// Une panique sous Compress et Cache donne une 500 propre : ni début de
+// réponse retenu, ni en-têtes de cache ou de compression
+func TestRecoverWrapsMiddlewares(t *testing.T) {
+	captureLogs(t)
+
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html>début"))
+		panic("template cassé")
+	}), RequestID, AccessLog, Recover, Compress, Language, Cache)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d; expected 500", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "début") {
+		t.Error("the partial response was sent")
+	}
+	for _, name := range []string{"Content-Encoding", "ETag"} {
+		if v := rec.Header().Get(name); v != "" {
+			t.Errorf("%s = %q on the error response", name, v)
+		}
+	}
+}
+
+// Une page d'erreur après panique suit la langue du visiteur et la requête
+// est comptée en 500, alors que Language et Metrics sont sous Recover
+func TestRecoverLanguageAndMetrics(t *testing.T) {
+	captureLogs(t)
+	if err := UseTemplates(os.DirFS("../templates"), false); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { templates = nil })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/test/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("template cassé")
+	})
+	h := Chain(mux, RequestID, AccessLog, Recover, Metrics(mux), Compress, Language, Cache)
+
+	req := httptest.NewRequest("GET", "/test/panic?lang=en", nil)
+	req.Header.Set("Accept-Language", "en")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(body, `<html lang="en">`) || !strings.Contains(body, "Error 500") {
+		t.Errorf("GET /test/panic?lang=en = %d; expected an English 500 page:\n%s", rec.Code, body)
+	}
+
+	var buf bytes.Buffer
+	metrics.Default.WriteText(&buf)
+	if !strings.Contains(buf.String(), `groupie_http_requests_total{route="/test/panic",method="GET",status="500"} 1`) {
+		t.Errorf("panicked request not counted as 500:\n%s", buf.String())
+	}
+}
+
+func TestRequestIDGenerated(t *testing.T) {
+	captureLogs(t)
+
+	var seen string
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFrom(r.Context())
+	}), RequestID, AccessLog)
+
+	for _, header := range []string{"", "avec espace", strings.Repeat("x", 200)} {
+		req := httptest.NewRequest("GET", "/", nil)
+		if header != "" {
+			req.Header.Set("X-Request-ID", header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if seen == "" || seen == header || rec.Header().Get("X-Request-ID") != seen {
+			t.Errorf("X-Request-ID %q: handler saw %q, response %q; expected a fresh ID",
+				header, seen, rec.Header().Get("X-Request-ID"))
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"api-groupie-tracker/graphql"
+	"api-groupie-tracker/models"
+)
+
+/*
+	Spécification OpenAPI 3 des endpoints JSON
+	➡️ schémas générés par réflexion depuis les types encodés par les handlers
+	➡️ chaque route /api/ de main.go doit figurer dans Paths (main_test.go)
+*/
+type openAPISpec struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       openAPIInfo            `json:"info"`
+	Servers    []openAPIServer        `json:"servers,omitempty"`
+	Paths      map[string]openAPIPath `json:"paths"`
+	Components openAPIComponents      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+// openAPIPath associe une méthode HTTP ("get", "post") à son opération
+type openAPIPath map[string]*openAPIOperation
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Schema      *jsonSchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                    `json:"required,omitempty"`
+	Content  map[string]openAPIMedia `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                  `json:"description"`
+	Content     map[string]openAPIMedia `json:"content,omitempty"`
+}
+
+type openAPIMedia struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*jsonSchema `json:"schemas"`
+}
+
+// jsonSchema est le sous-ensemble de JSON Schema utilisé par la spécification
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+}
+
+// schemaRegistry accumule les schémas nommés (components/schemas)
+type schemaRegistry map[string]*jsonSchema
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	localDateType = reflect.TypeOf(models.LocalDate{})
+)
+
+// Préfixes des schémas issus de paquets dont les noms de types sont génériques
+var schemaPrefixes = map[string]string{
+	reflect.TypeOf(graphql.Error{}).PkgPath(): "GraphQL",
+}
+
+// of retourne le schéma d'une valeur Go tel que encoding/json l'encode. Les
+// structs nommées sont enregistrées dans le registre et référencées.
+func (reg schemaRegistry) of(v interface{}) *jsonSchema {
+	return reg.schemaFor(reflect.TypeOf(v))
+}
+
+func (reg schemaRegistry) schemaFor(t reflect.Type) *jsonSchema {
+	switch t {
+	case timeType:
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case localDateType:
+		return &jsonSchema{Type: "string", Format: "date"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return reg.schemaFor(t.Elem())
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: reg.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: reg.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return reg.structSchema(t)
+		}
+		name := schemaPrefixes[t.PkgPath()] + t.Name()
+		if _, exists := reg[name]; !exists {
+			reg[name] = &jsonSchema{} // réservé avant récursion
+			reg[name] = reg.structSchema(t)
+		}
+		return &jsonSchema{Ref: "#/components/schemas/" + name}
+	}
+
+	// interface{} : valeur JSON quelconque
+	return &jsonSchema{}
+}
+
+func (reg schemaRegistry) structSchema(t reflect.Type) *jsonSchema {
+	schema := &jsonSchema{Type: "object", Properties: make(map[string]*jsonSchema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		// Struct embarquée sans tag : ses champs sont promus (ex. NearbyConcert)
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for key, prop := range reg.structSchema(f.Type).Properties {
+				schema.Properties[key] = prop
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		schema.Properties[name] = reg.schemaFor(f.Type)
+	}
+	return schema
+}
+
+func queryParam(name, description string, schema *jsonSchema) openAPIParameter {
+	return openAPIParameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func jsonContent(schema *jsonSchema) map[string]openAPIMedia {
+	return map[string]openAPIMedia{"application/json": {Schema: schema}}
+}
+
+func float(v float64) *float64 {
+	return &v
+}
+
+var (
+	openAPIOnce     sync.Once
+	openAPIDocument openAPISpec
+)
+
+// buildOpenAPISpec décrit les endpoints JSON et d'export de l'application
+func buildOpenAPISpec() openAPISpec {
+	reg := schemaRegistry{}
+
+	str := &jsonSchema{Type: "string"}
+	integer := &jsonSchema{Type: "integer"}
+	date := &jsonSchema{Type: "string", Format: "date"}
+	errorResponse := func(description string) openAPIResponse {
+		return openAPIResponse{Description: description, Content: map[string]openAPIMedia{
+			"application/problem+json": {Schema: reg.of(Problem{})},
+		}}
+	}
+
+	concertFilters := []openAPIParameter{
+		queryParam("artist", "Identifiant d'artiste (répétable)", &jsonSchema{Type: "array", Items: integer}),
+		queryParam("location", "Sous-chaîne de la location (ex. lyon)", str),
+		queryParam("country", "Pays (ex. france)", str),
+		queryParam("from", "Date minimale incluse (AAAA-MM-JJ)", date),
+		queryParam("to", "Date maximale incluse (AAAA-MM-JJ)", date),
+	}
+
+	artistID := openAPIParameter{Name: "id", In: "path", Required: true, Description: "Identifiant de l'artiste", Schema: integer}
+
+	graphQLResult := &jsonSchema{
+		Type: "object",
+		Properties: map[string]*jsonSchema{
+			"data":   {Type: "object", Description: "Absent si la requête est invalide"},
+			"errors": {Type: "array", Items: reg.of(graphql.Error{})},
+		},
+	}
+
+	exportParams := append([]openAPIParameter{
+		{Name: "file", In: "path", Required: true, Schema: &jsonSchema{Type: "string", Enum: []string{"artists.csv", "artists.ndjson", "concerts.csv", "concerts.ndjson"}}},
+		queryParam("q", "Recherche plein texte, comme /search", str),
+		queryParam("creation_min", "Année de création minimale", integer),
+		queryParam("creation_max", "Année de création maximale", integer),
+		queryParam("album_min", "Année du premier album minimale", integer),
+		queryParam("album_max", "Année du premier album maximale", integer),
+		queryParam("members_min", "Nombre de membres minimal", integer),
+		queryParam("members_max", "Nombre de membres maximal", integer),
+		queryParam("locations", "Location (répétable)", &jsonSchema{Type: "array", Items: str}),
+	}, concertFilters...)
+
+	paths := map[string]openAPIPath{
+		"/api/openapi.json": {"get": {
+			OperationID: "getOpenAPI",
+			Summary:     "Cette spécification",
+			Tags:        []string{"meta"},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Document OpenAPI 3", Content: jsonContent(&jsonSchema{Type: "object"})},
+			},
+		}},
+		"/healthz": {"get": {
+			OperationID: "getHealth",
+			Summary:     "Sonde de vie : le processus répond",
+			Tags:        []string{"meta"},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Serveur en vie", Content: jsonContent(reg.of(HealthResponse{}))},
+			},
+		}},
+		"/readyz": {"get": {
+			OperationID: "getReadiness",
+			Summary:     "Sonde de disponibilité : données chargées et récentes, templates analysés",
+			Tags:        []string{"meta"},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Prêt à servir des pages", Content: jsonContent(reg.of(ReadinessResponse{}))},
+				"503": {Description: "Indisponible ; le détail figure dans checks", Content: jsonContent(reg.of(ReadinessResponse{}))},
+			},
+		}},
+		"/api/suggestions": {"get": {
+			OperationID: "getSuggestions",
+			Summary:     "Suggestions de recherche (artistes, membres, lieux, dates)",
+			Tags:        []string{"search"},
+			Parameters:  []openAPIParameter{queryParam("q", "Texte saisi", str)},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Suggestions", Content: jsonContent(reg.of([]models.SearchSuggestion{}))},
+				"429": errorResponse("Trop de requêtes ; voir l'en-tête Retry-After"),
+			},
+		}},
+		"/api/v1/concerts/nearby": {"get": {
+			OperationID: "getNearbyConcerts",
+			Summary:     "Concerts dans un rayon autour d'un point ou d'une location connue",
+			Tags:        []string{"concerts"},
+			Parameters: []openAPIParameter{
+				queryParam("lat", "Latitude (requise avec lon, sinon location)", &jsonSchema{Type: "number", Minimum: float(-90), Maximum: float(90)}),
+				queryParam("lon", "Longitude", &jsonSchema{Type: "number", Minimum: float(-180), Maximum: float(180)}),
+				queryParam("location", "Location connue (ex. lyon-france)", str),
+				queryParam("radius_km", "Rayon en kilomètres", &jsonSchema{Type: "number", Default: defaultRadiusKm, Maximum: float(maxRadiusKm)}),
+				queryParam("from", "Date minimale incluse (AAAA-MM-JJ)", date),
+				queryParam("to", "Date maximale incluse (AAAA-MM-JJ)", date),
+			},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Concerts triés par distance puis par date", Content: jsonContent(reg.of(NearbyResponse{}))},
+				"400": errorResponse("Paramètres invalides"),
+			},
+		}},
+		"/api/v1/artists/{id}/overlaps": {"get": {
+			OperationID: "getArtistOverlaps",
+			Summary:     "Concerts partagés avec d'autres artistes (même lieu, dates proches)",
+			Tags:        []string{"artists"},
+			Parameters:  []openAPIParameter{artistID},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Co-plateaux", Content: jsonContent(reg.of(OverlapsResponse{}))},
+				"400": errorResponse("Identifiant invalide"),
+				"404": errorResponse("Artiste inconnu"),
+			},
+		}},
+		"/api/v1/artists/{id}/map": {"get": {
+			OperationID: "getArtistMap",
+			Summary:     "Lieux de concert d'un artiste avec coordonnées et dates",
+			Tags:        []string{"artists"},
+			Parameters:  []openAPIParameter{artistID},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Lieux de concert", Content: jsonContent(reg.of(MapResponse{}))},
+				"400": errorResponse("Identifiant invalide"),
+				"404": errorResponse("Artiste inconnu"),
+			},
+		}},
+		"/api/v1/artists/{id}/similar": {"get": {
+			OperationID: "getSimilarArtists",
+			Summary:     "Artistes similaires",
+			Tags:        []string{"artists"},
+			Parameters:  []openAPIParameter{artistID},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Recommandations", Content: jsonContent(reg.of(SimilarResponse{}))},
+				"400": errorResponse("Identifiant invalide"),
+				"404": errorResponse("Artiste inconnu"),
+			},
+		}},
+		"/api/v1/stats": {"get": {
+			OperationID: "getStats",
+			Summary:     "Statistiques agrégées du jeu de données",
+			Tags:        []string{"stats"},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Statistiques", Content: jsonContent(reg.of(models.Stats{}))},
+			},
+		}},
+		"/api/v1/concerts.ics": {"get": {
+			OperationID: "getConcertsICS",
+			Summary:     "Calendrier iCalendar des concerts filtrés",
+			Tags:        []string{"concerts"},
+			Parameters:  concertFilters,
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Calendrier RFC 5545", Content: map[string]openAPIMedia{"text/calendar": {Schema: str}}},
+				"400": errorResponse("Paramètres invalides"),
+			},
+		}},
+		"/api/v1/export/{file}": {"get": {
+			OperationID: "getExport",
+			Summary:     "Export CSV ou NDJSON des artistes ou des concerts filtrés",
+			Tags:        []string{"export"},
+			Parameters:  exportParams,
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "Fichier exporté", Content: map[string]openAPIMedia{
+					"text/csv":             {Schema: str},
+					"application/x-ndjson": {Schema: str},
+				}},
+				"400": errorResponse("Paramètres invalides"),
+				"404": errorResponse("Export inconnu"),
+			},
+		}},
+		"/graphql": {
+			"get": {
+				OperationID: "getGraphQL",
+				Summary:     "Requête GraphQL (artistes, membres, concerts, locations)",
+				Tags:        []string{"graphql"},
+				Parameters: []openAPIParameter{
+					{Name: "query", In: "query", Required: true, Schema: str},
+					queryParam("variables", "Objet JSON des variables", str),
+					queryParam("operationName", "Opération à exécuter", str),
+				},
+				Responses: map[string]openAPIResponse{
+					"200": {Description: "Résultat", Content: jsonContent(graphQLResult)},
+					"400": {Description: "Requête invalide", Content: jsonContent(graphQLResult)},
+				},
+			},
+			"post": {
+				OperationID: "postGraphQL",
+				Summary:     "Requête GraphQL (artistes, membres, concerts, locations)",
+				Tags:        []string{"graphql"},
+				RequestBody: &openAPIRequestBody{Required: true, Content: jsonContent(reg.of(graphql.Request{}))},
+				Responses: map[string]openAPIResponse{
+					"200": {Description: "Résultat", Content: jsonContent(graphQLResult)},
+					"400": {Description: "Requête invalide", Content: jsonContent(graphQLResult)},
+				},
+			},
+		},
+	}
+
+	return openAPISpec{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       "Groupie Tracker API",
+			Description: "Artistes, concerts et locations issus de l'API Groupie Trackers.",
+			Version:     "1.0.0",
+		},
+		Paths:      paths,
+		Components: openAPIComponents{Schemas: reg},
+	}
+}
+
+// =======================
+// OPENAPI
+// =======================
+func OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	openAPIOnce.Do(func() {
+		openAPIDocument = buildOpenAPISpec()
+	})
+
+	spec := openAPIDocument
+	spec.Servers = []openAPIServer{{URL: baseURL(r)}}
+	writeJSON(w, http.StatusOK, spec)
+}
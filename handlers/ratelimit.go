@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit est la limite d'une route : Rate requêtes par seconde en
+// régime continu, avec des rafales jusqu'à Burst requêtes
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limites par route. Les suggestions sont demandées à chaque frappe (après
+// un délai de 300 ms côté navigateur) ; la recherche complète est plus coûteuse.
+var (
+	SuggestionsLimit = RateLimit{Rate: 5, Burst: 20}
+	SearchLimit      = RateLimit{Rate: 1, Burst: 10}
+)
+
+// Intervalle entre deux balayages des compteurs inactifs
+const rateLimitSweepInterval = time.Minute
+
+// En-tête (X-Forwarded-For, X-Real-IP...) donnant l'IP du client derrière
+// un proxy de confiance. Vide, seule l'adresse de connexion est utilisée.
+var trustedProxyHeader string
+
+// SetTrustedProxyHeader fixe l'en-tête lu pour identifier le client
+func SetTrustedProxyHeader(header string) {
+	trustedProxyHeader = http.CanonicalHeaderKey(header)
+}
+
+/*
+	tokenBucket
+	➡️ jetons restants d'un client, recalculés à chaque requête
+*/
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+/*
+	rateLimiter
+	➡️ un seau de jetons par IP de client, pour une route
+*/
+type rateLimiter struct {
+	limit RateLimit
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	return &rateLimiter{limit: limit, buckets: make(map[string]*tokenBucket)}
+}
+
+// allow consomme un jeton du client key. Sans jeton disponible, retourne
+// le délai avant le prochain.
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	b.tokens = math.Min(float64(l.limit.Burst), b.tokens+elapsed*l.limit.Rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := (1 - b.tokens) / l.limit.Rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// sweep supprime les seaux redevenus pleins : un client absent depuis ce
+// délai retrouverait de toute façon toutes ses rafales
+func (l *rateLimiter) sweep(now time.Time) {
+	refill := time.Duration(float64(l.limit.Burst) / l.limit.Rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// RateLimited limite le nombre de requêtes de chaque client sur next. Au-delà,
+// la réponse est un 429 avec l'en-tête Retry-After.
+func RateLimited(limit RateLimit, next http.HandlerFunc) http.HandlerFunc {
+	limiter := newRateLimiter(limit)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ok, wait := limiter.allow(clientIP(r), time.Now())
+		if !ok {
+			seconds := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			writeError(w, r, newError(http.StatusTooManyRequests, "error.tooManyRequests", seconds))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// clientIP identifie le client : l'en-tête du proxy de confiance s'il est
+// configuré et valide, sinon l'adresse de la connexion
+func clientIP(r *http.Request) string {
+	if trustedProxyHeader != "" {
+		// Le proxy ajoute l'adresse qu'il voit en dernier ; les précédentes
+		// viennent du client et ne sont pas fiables
+		values := strings.Split(r.Header.Get(trustedProxyHeader), ",")
+		if ip := net.ParseIP(strings.TrimSpace(values[len(values)-1])); ip != nil {
+			return ip.String()
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
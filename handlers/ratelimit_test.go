@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	limiter := newRateLimiter(RateLimit{Rate: 2, Burst: 3})
+	now := time.Now()
+
+	// La rafale passe, la requête suivante attend un demi-jeton
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.allow("1.2.3.4", now); !ok {
+			t.Fatalf("request %d rejected within the burst", i+1)
+		}
+	}
+	ok, wait := limiter.allow("1.2.3.4", now)
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("allow() after the burst = (%v, %s); expected (false, 500ms)", ok, wait)
+	}
+
+	// Les clients sont indépendants
+	if ok, _ := limiter.allow("5.6.7.8", now); !ok {
+		t.Error("another client was rejected")
+	}
+
+	// Les jetons se rechargent au rythme de Rate
+	if ok, _ := limiter.allow("1.2.3.4", now.Add(500*time.Millisecond)); !ok {
+		t.Error("request rejected after refill")
+	}
+
+	// Les seaux inactifs sont supprimés au balayage suivant
+	limiter.allow("9.9.9.9", now.Add(rateLimitSweepInterval))
+	if _, ok := limiter.buckets["5.6.7.8"]; ok || len(limiter.buckets) != 1 {
+		t.Errorf("buckets after sweep = %d; expected only the new client", len(limiter.buckets))
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	h := RateLimited(RateLimit{Rate: 0.1, Burst: 1}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	request := func(remoteAddr, forwarded string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/suggestions?q=qu", nil)
+		req.RemoteAddr = remoteAddr
+		if forwarded != "" {
+			req.Header.Set("X-Forwarded-For", forwarded)
+		}
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	if rec := request("10.0.0.1:5000", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("first request = %d; expected 204", rec.Code)
+	}
+	rec := request("10.0.0.1:5001", "")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "10" {
+		t.Errorf("second request = %d, Retry-After %q; expected 429, 10", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q; expected application/problem+json", ct)
+	}
+
+	// Derrière un proxy de confiance, le client est la dernière adresse de
+	// l'en-tête ; les valeurs précédentes, fournies par le client, sont ignorées
+	SetTrustedProxyHeader("x-forwarded-for")
+	t.Cleanup(func() { SetTrustedProxyHeader("") })
+
+	if rec := request("10.0.0.1:5002", "203.0.113.7"); rec.Code != http.StatusNoContent {
+		t.Errorf("proxied client = %d; expected 204", rec.Code)
+	}
+	if rec := request("10.0.0.1:5003", "198.51.100.1, 203.0.113.7"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("spoofed X-Forwarded-For = %d; expected 429", rec.Code)
+	}
+}
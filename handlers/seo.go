@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/models"
+	"api-groupie-tracker/utils"
+)
+
+// PageMeta contient les métadonnées de partage (Open Graph, Twitter)
+type PageMeta struct {
+	Title       string
+	Description string
+	URL         string
+	Image       string
+	Type        string
+}
+
+// Structures schema.org sérialisées en JSON-LD
+type musicGroupLD struct {
+	Context      string         `json:"@context"`
+	Type         string         `json:"@type"`
+	Name         string         `json:"name"`
+	URL          string         `json:"url"`
+	Image        string         `json:"image,omitempty"`
+	FoundingDate string         `json:"foundingDate,omitempty"`
+	Members      []personLD     `json:"member,omitempty"`
+	Events       []musicEventLD `json:"event,omitempty"`
+}
+
+type personLD struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type musicEventLD struct {
+	Type                string      `json:"@type"`
+	Name                string      `json:"name"`
+	StartDate           string      `json:"startDate"`
+	EventAttendanceMode string      `json:"eventAttendanceMode"`
+	Location            placeLD     `json:"location"`
+	Performer           performerLD `json:"performer"`
+}
+
+type placeLD struct {
+	Type    string          `json:"@type"`
+	Name    string          `json:"name"`
+	Address string          `json:"address"`
+	Geo     *geoCoordinates `json:"geo,omitempty"`
+}
+
+type geoCoordinates struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type performerLD struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// artistMeta construit les métadonnées de partage d'une page artiste
+func artistMeta(r *http.Request, lang string, artist models.FullArtist) PageMeta {
+	description := i18n.T(lang, "artist.description", artist.Name, artist.CreationDate)
+	if len(artist.Members) > 0 {
+		description = i18n.T(lang, "artist.descriptionMembers", artist.Name, artist.CreationDate, strings.Join(artist.Members, ", "))
+	}
+	firstAlbum := artist.FirstAlbum
+	if album := utils.ParseLocalDate(firstAlbum); !album.IsZero() {
+		firstAlbum = i18n.FormatDate(lang, album.Time)
+	}
+	description += ". " + i18n.T(lang, "artist.firstAlbum", firstAlbum)
+
+	return PageMeta{
+		Title:       artist.Name + " - Groupie Tracker",
+		Description: description,
+		URL:         baseURL(r) + "/artist/" + strconv.Itoa(artist.ID),
+		Image:       absoluteURL(r, artist.Image),
+		Type:        "profile",
+	}
+}
+
+// absoluteURL résout ref par rapport au site : les réseaux sociaux et les
+// moteurs de recherche n'acceptent que des URLs absolues
+func absoluteURL(r *http.Request, ref string) string {
+	u, err := url.Parse(ref)
+	if ref == "" || err != nil || u.IsAbs() {
+		return ref
+	}
+	base, err := url.Parse(baseURL(r) + "/")
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// artistJSONLD sérialise l'artiste en MusicGroup avec un MusicEvent par concert.
+// json.Marshal échappe <, > et &, le résultat peut donc être inséré tel quel
+// dans une balise <script>.
+func artistJSONLD(r *http.Request, artist models.FullArtist) template.JS {
+	pageURL := baseURL(r) + "/artist/" + strconv.Itoa(artist.ID)
+
+	group := musicGroupLD{
+		Context: "https://schema.org",
+		Type:    "MusicGroup",
+		Name:    artist.Name,
+		URL:     pageURL,
+		Image:   absoluteURL(r, artist.Image),
+	}
+	if artist.CreationDate > 0 {
+		group.FoundingDate = strconv.Itoa(artist.CreationDate)
+	}
+
+	for _, member := range artist.Members {
+		group.Members = append(group.Members, personLD{Type: "Person", Name: member})
+	}
+
+	concerts := utils.FilterConcerts(api.GetConcerts(), models.ConcertCriteria{ArtistIDs: []int{artist.ID}})
+	for _, concert := range concerts {
+		place := utils.FormatLocation(concert.Location)
+		event := musicEventLD{
+			Type:                "MusicEvent",
+			Name:                artist.Name + " - " + place,
+			StartDate:           concert.Date.Format(queryDateLayout),
+			EventAttendanceMode: "https://schema.org/OfflineEventAttendanceMode",
+			Location: placeLD{
+				Type:    "Place",
+				Name:    place,
+				Address: place,
+			},
+			Performer: performerLD{Type: "MusicGroup", Name: artist.Name, URL: pageURL},
+		}
+		if p, ok := api.GetLocationPosition(concert.Location); ok {
+			event.Location.Geo = &geoCoordinates{Type: "GeoCoordinates", Latitude: p.Lat, Longitude: p.Lon}
+		}
+		group.Events = append(group.Events, event)
+	}
+
+	data, err := json.Marshal(group)
+	if err != nil {
+		return ""
+	}
+	return template.JS(data)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestBaseURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		publicURL string
+		proxy     string
+		host      string
+		forwarded string
+		expected  string
+	}{
+		{"request host", "", "", "localhost:8080", "", "http://localhost:8080"},
+		{"untrusted proto", "", "", "localhost:8080", "https", "http://localhost:8080"},
+		{"trusted proto", "", "X-Forwarded-For", "groupie.example", "https", "https://groupie.example"},
+		{"public url", "https://groupie.example/", "", "evil.example", "http", "https://groupie.example"},
+	}
+	t.Cleanup(func() {
+		SetPublicURL("")
+		SetTrustedProxyHeader("")
+	})
+
+	for _, tt := range tests {
+		SetPublicURL(tt.publicURL)
+		SetTrustedProxyHeader(tt.proxy)
+
+		req := httptest.NewRequest("GET", "/sitemap.xml", nil)
+		req.Host = tt.host
+		if tt.forwarded != "" {
+			req.Header.Set("X-Forwarded-Proto", tt.forwarded)
+		}
+		if got := baseURL(req); got != tt.expected {
+			t.Errorf("%s: baseURL = %q; expected %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+// La page artiste décrit le groupe et ses concerts en JSON-LD et en Open
+// Graph, avec des URLs absolues, sans qu'un nom puisse fermer la balise
+func TestArtistStructuredData(t *testing.T) {
+	const name = `Queen</script><script>alert(1)</script>`
+	loadArtist(t, map[string]interface{}{"id": 1, "name": name, "image": "/images/queen.jpeg"},
+		[]string{"london-uk", "paris-france"})
+
+	req := httptest.NewRequest("GET", "/artist/1", nil)
+	req.Host = "groupie.example"
+	rec := httptest.NewRecorder()
+	ArtistHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /artist/1 = %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, "<script>alert(1)") {
+		t.Error("the artist name was written unescaped in the page")
+	}
+
+	const open = `<script type="application/ld+json">`
+	start := strings.Index(body, open)
+	if start < 0 {
+		t.Fatal("JSON-LD block not found")
+	}
+	block, _, _ := strings.Cut(body[start+len(open):], "</script>")
+
+	var group struct {
+		Type   string `json:"@type"`
+		Name   string `json:"name"`
+		URL    string `json:"url"`
+		Image  string `json:"image"`
+		Events []struct {
+			Type string `json:"@type"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal([]byte(block), &group); err != nil {
+		t.Fatalf("JSON-LD block is not valid JSON (%v): %s", err, block)
+	}
+	if group.Type != "MusicGroup" || group.Name != name {
+		t.Errorf("JSON-LD = %s %q; expected MusicGroup %q", group.Type, group.Name, name)
+	}
+	if len(group.Events) != 2 {
+		t.Errorf("JSON-LD has %d events; expected one per concert (2)", len(group.Events))
+	}
+	for _, event := range group.Events {
+		if event.Type != "MusicEvent" {
+			t.Errorf("event type = %s; expected MusicEvent", event.Type)
+		}
+	}
+
+	urls := map[string]string{"JSON-LD url": group.URL, "JSON-LD image": group.Image}
+	for _, property := range []string{"og:url", "og:image"} {
+		match := regexp.MustCompile(`<meta property="` + property + `" content="([^"]*)"`).FindStringSubmatch(body)
+		if match == nil {
+			t.Errorf("%s not found", property)
+			continue
+		}
+		urls[property] = match[1]
+	}
+	for field, value := range urls {
+		if u, err := url.Parse(value); err != nil || !u.IsAbs() || u.Host != "groupie.example" {
+			t.Errorf("%s = %q; expected an absolute URL on groupie.example", field, value)
+		}
+	}
+}
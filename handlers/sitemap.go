@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"api-groupie-tracker/api"
+)
+
+// Structures du protocole sitemaps.org
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+// =======================
+// SITEMAP & ROBOTS
+// =======================
+func SitemapHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		ErrorHandler(w, r, http.StatusMethodNotAllowed)
+		return
+	}
+
+	base := baseURL(r)
+	lastmod := ""
+	if refreshed := api.GetLastRefresh(); !refreshed.IsZero() {
+		lastmod = refreshed.Format(time.RFC3339)
+	}
+
+	set := sitemapURLSet{}
+	add := func(path, freq, priority string) {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:        base + path,
+			LastMod:    lastmod,
+			ChangeFreq: freq,
+			Priority:   priority,
+		})
+	}
+
+	// Pages rendues côté serveur
+	add("/", "daily", "1.0")
+	add("/calendar", "daily", "0.6")
+	add("/stats", "weekly", "0.5")
+
+	for _, artist := range api.GetAllArtists() {
+		add("/artist/"+strconv.Itoa(artist.ID), "weekly", "0.8")
+	}
+	for _, location := range api.GetLocationNames() {
+		add("/location/"+url.PathEscape(location), "weekly", "0.4")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(set); err != nil {
+		writeError(w, r, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// RobotsHandler autorise les pages publiques et annonce le sitemap. Les
+// recherches, filtres, API et GraphQL sont exclus pour éviter l'exploration
+// de combinaisons de paramètres infinies.
+func RobotsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "User-agent: *\n")
+	fmt.Fprintf(w, "Allow: /\n")
+	fmt.Fprintf(w, "Disallow: /search\n")
+	fmt.Fprintf(w, "Disallow: /filter\n")
+	fmt.Fprintf(w, "Disallow: /api/\n")
+	fmt.Fprintf(w, "Disallow: /graphql\n")
+	fmt.Fprintf(w, "Disallow: /metrics\n")
+	fmt.Fprintf(w, "\nSitemap: %s/sitemap.xml\n", baseURL(r))
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSitemapListsEveryPage(t *testing.T) {
+	loadArtistLocations(t, []string{"london-uk", "são_paulo-brazil"})
+	SetPublicURL("https://groupie.example")
+	t.Cleanup(func() { SetPublicURL("") })
+
+	rec := httptest.NewRecorder()
+	SitemapHandler(rec, httptest.NewRequest("GET", "/sitemap.xml", nil))
+
+	var set struct {
+		XMLName xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+		URLs    []struct {
+			Loc string `xml:"loc"`
+		} `xml:"url"`
+	}
+	if err := xml.Unmarshal(rec.Body.Bytes(), &set); err != nil {
+		t.Fatalf("sitemap is not valid XML (%v): %s", err, rec.Body.String())
+	}
+
+	listed := make(map[string]bool)
+	for _, u := range set.URLs {
+		listed[u.Loc] = true
+	}
+	for _, expected := range []string{
+		"https://groupie.example/",
+		"https://groupie.example/artist/1",
+		"https://groupie.example/location/london-uk",
+		"https://groupie.example/location/s%C3%A3o_paulo-brazil",
+	} {
+		if !listed[expected] {
+			t.Errorf("sitemap does not list %s", expected)
+		}
+	}
+}
+
+func TestRobotsPointsToSitemap(t *testing.T) {
+	SetPublicURL("https://groupie.example")
+	t.Cleanup(func() { SetPublicURL("") })
+
+	rec := httptest.NewRecorder()
+	RobotsHandler(rec, httptest.NewRequest("GET", "/robots.txt", nil))
+
+	body := rec.Body.String()
+	for _, line := range []string{"Sitemap: https://groupie.example/sitemap.xml", "Disallow: /graphql"} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("robots.txt does not contain %q:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"api-groupie-tracker/api"
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/models"
+)
+
+// =======================
+// STATS
+// =======================
+func StatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ErrorHandler(w, r, http.StatusMethodNotAllowed)
+		return
+	}
+
+	render(w, r, "stats.html", localizeStats(requestLang(r), api.GetStats()))
+}
+
+// StatsAPIHandler sert les mêmes statistiques au format JSON
+func StatsAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, newError(http.StatusMethodNotAllowed, "error.methodNotAllowed"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, localizeStats(requestLang(r), api.GetStats()))
+}
+
+// localizeStats traduit les libellés des barres données par une clé du
+// catalogue. Les statistiques partagées par api ne sont pas modifiées : les
+// barres sont copiées.
+func localizeStats(lang string, stats models.Stats) models.Stats {
+	for _, bars := range []*[]models.StatBar{
+		&stats.ArtistsPerDecade, &stats.BandSizeByDecade, &stats.BusiestCountries,
+		&stats.BusiestCities, &stats.ConcertsPerYear, &stats.MostTravelled, &stats.CreationToAlbum,
+	} {
+		localized := make([]models.StatBar, len(*bars))
+		for i, bar := range *bars {
+			if bar.Key != "" {
+				bar.Label = i18n.T(lang, bar.Key, bar.Args...)
+			}
+			localized[i] = bar
+		}
+		*bars = localized
+	}
+	return stats
+}
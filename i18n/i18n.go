@@ -0,0 +1,171 @@
+// Package i18n traduit les textes de l'interface en français et en anglais
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Langues prises en charge
+const (
+	French  = "fr"
+	English = "en"
+)
+
+// Default est la langue utilisée quand le client n'en demande aucune
+const Default = French
+
+// Languages liste les langues du catalogue, la langue par défaut en tête
+var Languages = []string{French, English}
+
+// Supported indique si lang est une langue du catalogue
+func Supported(lang string) bool {
+	_, ok := messages[lang]
+	return ok
+}
+
+// T retourne le message key dans la langue lang, formaté avec args. Un
+// message absent est cherché dans la langue par défaut, puis key est
+// retournée telle quelle, pour qu'un oubli du catalogue reste visible.
+func T(lang, key string, args ...interface{}) string {
+	message, ok := messages[lang][key]
+	if !ok {
+		if message, ok = messages[Default][key]; !ok {
+			message = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// N retourne la forme singulier (key.one) ou pluriel (key.other) du message
+// selon n, formatée avec n. En français, 0 est singulier.
+func N(lang, key string, n int) string {
+	one := n == 1 || (n == 0 && lang == French)
+	if one {
+		return T(lang, key+".one", n)
+	}
+	return T(lang, key+".other", n)
+}
+
+// MonthName retourne le nom du mois dans la langue lang
+func MonthName(lang string, month time.Month) string {
+	if month < time.January || month > time.December {
+		return ""
+	}
+	return T(lang, "month."+strconv.Itoa(int(month)))
+}
+
+// FormatDate écrit une date en toutes lettres : "21 août 2019" ou
+// "August 21, 2019"
+func FormatDate(lang string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	month := MonthName(lang, t.Month())
+	if lang == English {
+		return fmt.Sprintf("%s %d, %d", month, t.Day(), t.Year())
+	}
+	day := strconv.Itoa(t.Day())
+	if t.Day() == 1 {
+		day = "1er"
+	}
+	return fmt.Sprintf("%s %s %d", day, month, t.Year())
+}
+
+// FormatShortDate écrit une date en chiffres : "21/08/2019" ou "08/21/2019"
+func FormatShortDate(lang string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(T(lang, "date.short"))
+}
+
+// FormatRelative situe t par rapport à now, au jour près : "aujourd'hui",
+// "dans 3 semaines", "il y a 5 ans"...
+func FormatRelative(lang string, t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	// Écart en jours calendaires, indépendant de l'heure
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	days := int(day(t).Sub(day(now)).Hours() / 24)
+
+	switch days {
+	case 0:
+		return T(lang, "relative.today")
+	case 1:
+		return T(lang, "relative.tomorrow")
+	case -1:
+		return T(lang, "relative.yesterday")
+	}
+
+	n := days
+	if n < 0 {
+		n = -n
+	}
+	var amount string
+	switch {
+	case n < 14:
+		amount = N(lang, "unit.day", n)
+	case n < 60:
+		amount = N(lang, "unit.week", n/7)
+	case n < 365:
+		amount = N(lang, "unit.month", n/30)
+	default:
+		amount = N(lang, "unit.year", n/365)
+	}
+
+	if days > 0 {
+		return T(lang, "relative.future", amount)
+	}
+	return T(lang, "relative.past", amount)
+}
+
+// Negotiate choisit la langue d'après l'en-tête Accept-Language, en
+// respectant les poids q ("en-GB,en;q=0.8,fr;q=0.5" → en). Seule la langue
+// principale compte. Sans correspondance, retourne Default.
+func Negotiate(header string) string {
+	type candidate struct {
+		lang  string
+		q     float64
+		order int
+	}
+
+	var candidates []candidate
+	for i, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		lang, _, _ := strings.Cut(tag, "-")
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 && (Supported(lang) || lang == "*") {
+			candidates = append(candidates, candidate{lang, q, i})
+		}
+	}
+
+	// Poids décroissant, puis ordre de l'en-tête
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	for _, c := range candidates {
+		if c.lang == "*" {
+			return Default
+		}
+		return c.lang
+	}
+	return Default
+}
@@ -0,0 +1,135 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCatalogueComplete(t *testing.T) {
+	for _, lang := range Languages {
+		for key := range messages[Default] {
+			if _, ok := messages[lang][key]; !ok {
+				t.Errorf("%s: missing %q", lang, key)
+			}
+		}
+		for key := range messages[lang] {
+			if _, ok := messages[Default][key]; !ok {
+				t.Errorf("%s: %q is not in the default catalogue", lang, key)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(English, "error.title", 404); got != "Error 404" {
+		t.Errorf("T(en, error.title) = %q", got)
+	}
+	if got := T("de", "error.title", 404); got != "Erreur 404" {
+		t.Errorf("T(de, error.title) = %q; expected the French fallback", got)
+	}
+	if got := T(English, "date invalide : 32-01-2020"); got != "date invalide : 32-01-2020" {
+		t.Errorf("T with an unknown key = %q; expected the key unchanged", got)
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		lang     string
+		n        int
+		expected string
+	}{
+		{French, 0, "0 membre"},
+		{French, 1, "1 membre"},
+		{French, 4, "4 membres"},
+		{English, 0, "0 members"},
+		{English, 1, "1 member"},
+	}
+	for _, tt := range tests {
+		if got := N(tt.lang, "card.members", tt.n); got != tt.expected {
+			t.Errorf("N(%s, %d) = %q; expected %q", tt.lang, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		lang     string
+		date     time.Time
+		expected string
+	}{
+		{French, time.Date(2019, time.August, 21, 0, 0, 0, 0, time.UTC), "21 août 2019"},
+		{French, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), "1er janvier 2020"},
+		{English, time.Date(2019, time.August, 21, 0, 0, 0, 0, time.UTC), "August 21, 2019"},
+		{English, time.Time{}, ""},
+	}
+	for _, tt := range tests {
+		if got := FormatDate(tt.lang, tt.date); got != tt.expected {
+			t.Errorf("FormatDate(%s, %v) = %q; expected %q", tt.lang, tt.date, got, tt.expected)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]string{
+		"":                          Default,
+		"en":                        English,
+		"en-GB,en;q=0.8,fr;q=0.5":   English,
+		"fr-CH, fr;q=0.9, en;q=0.8": French,
+		"de, en;q=0.5":              English,
+		"de, *;q=0.5":               Default,
+		"en;q=0, fr;q=0.1":          French,
+		"es, de":                    Default,
+		"EN-us":                     English,
+		"en;q=abc":                  Default,
+		"fr;q=0.4, en;q=0.6":        English,
+	}
+	for header, expected := range tests {
+		if got := Negotiate(header); got != expected {
+			t.Errorf("Negotiate(%q) = %q; expected %q", header, got, expected)
+		}
+	}
+}
+
+func TestMonthName(t *testing.T) {
+	if got := MonthName(English, time.March); got != "March" {
+		t.Errorf("MonthName(en, March) = %q", got)
+	}
+	if got := MonthName(French, time.Month(13)); got != "" {
+		t.Errorf("MonthName(fr, 13) = %q; expected \"\"", got)
+	}
+}
+
+func TestFormatShortDate(t *testing.T) {
+	date := time.Date(2019, time.August, 21, 0, 0, 0, 0, time.UTC)
+	if got := FormatShortDate(French, date); got != "21/08/2019" {
+		t.Errorf("FormatShortDate(fr) = %q", got)
+	}
+	if got := FormatShortDate(English, date); got != "08/21/2019" {
+		t.Errorf("FormatShortDate(en) = %q", got)
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		lang     string
+		date     time.Time
+		expected string
+	}{
+		{French, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC), "aujourd'hui"},
+		{English, time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), "tomorrow"},
+		{French, time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC), "hier"},
+		{English, time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), "in 5 days"},
+		{English, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), "in 3 weeks"},
+		{French, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), "dans 3 semaines"},
+		{English, time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC), "3 months ago"},
+		{English, time.Date(2019, time.January, 2, 0, 0, 0, 0, time.UTC), "5 years ago"},
+		{French, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), "il y a 1 an"},
+		{French, time.Time{}, ""},
+	}
+	for _, tt := range tests {
+		if got := FormatRelative(tt.lang, tt.date, now); got != tt.expected {
+			t.Errorf("FormatRelative(%s, %s) = %q; expected %q", tt.lang, tt.date.Format("2006-01-02"), got, tt.expected)
+		}
+	}
+}
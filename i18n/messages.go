@@ -0,0 +1,403 @@
+package i18n
+
+// messages est le catalogue : pour chaque langue, les textes par clé.
+// Les formes plurielles utilisées par N ont les suffixes .one et .other.
+var messages = map[string]map[string]string{
+	French: {
+		"lang.fr": "FR",
+		"lang.en": "EN",
+
+		"site.footer":   "© 2024 Groupie Tracker - Projet Ynov",
+		"nav.back":      "← Retour",
+		"nav.home":      "Retour à l'accueil",
+		"nav.calendar":  "📅 Calendrier",
+		"nav.stats":     "📊 Statistiques",
+		"nav.languages": "Langue",
+
+		"month.1":  "janvier",
+		"month.2":  "février",
+		"month.3":  "mars",
+		"month.4":  "avril",
+		"month.5":  "mai",
+		"month.6":  "juin",
+		"month.7":  "juillet",
+		"month.8":  "août",
+		"month.9":  "septembre",
+		"month.10": "octobre",
+		"month.11": "novembre",
+		"month.12": "décembre",
+
+		"date.short": "02/01/2006",
+
+		"relative.today":     "aujourd'hui",
+		"relative.tomorrow":  "demain",
+		"relative.yesterday": "hier",
+		"relative.future":    "dans %s",
+		"relative.past":      "il y a %s",
+		"unit.day.one":       "%d jour",
+		"unit.day.other":     "%d jours",
+		"unit.week.one":      "%d semaine",
+		"unit.week.other":    "%d semaines",
+		"unit.month.one":     "%d mois",
+		"unit.month.other":   "%d mois",
+		"unit.year.one":      "%d an",
+		"unit.year.other":    "%d ans",
+
+		"weekday.mon": "Lun",
+		"weekday.tue": "Mar",
+		"weekday.wed": "Mer",
+		"weekday.thu": "Jeu",
+		"weekday.fri": "Ven",
+		"weekday.sat": "Sam",
+		"weekday.sun": "Dim",
+
+		"label.creationDate": "Date de création",
+		"label.firstAlbum":   "Premier album",
+		"label.members":      "Membres",
+
+		"feed.artists":     "Nouveaux artistes",
+		"feed.concerts":    "Nouveaux concerts",
+		"feed.newArtist":   "Nouvel artiste : %s",
+		"feed.artistAdded": "%s a été ajouté au Groupie Tracker.",
+		"feed.newConcert":  "Nouveau concert de %s à %s le %s.",
+
+		"search.placeholder":   "Rechercher un artiste, membre, location...",
+		"search.resultsFor":    "Résultats pour :",
+		"search.results.one":   "%d résultat",
+		"search.results.other": "%d résultats",
+
+		"suggestion.artist/band":      "artiste/groupe",
+		"suggestion.member":           "membre",
+		"suggestion.creation date":    "date de création",
+		"suggestion.first album date": "date du premier album",
+
+		"nearby.title":       "📍 Concerts à proximité",
+		"nearby.place":       "Lieu",
+		"nearby.coordinates": "Coordonnées ci-contre",
+		"nearby.latitude":    "Latitude",
+		"nearby.longitude":   "Longitude",
+		"nearby.radius":      "Rayon (km)",
+		"nearby.from":        "Du",
+		"nearby.to":          "Au",
+		"nearby.submit":      "Rechercher",
+		"nearby.error":       "Erreur lors de la recherche.",
+		"nearby.network":     "Erreur réseau.",
+		"nearby.none":        "Aucun concert dans ce rayon.",
+
+		"filters.title":           "🎛️ Filtres",
+		"filters.applied":         "✓ Filtres appliqués",
+		"filters.reset":           "Réinitialiser",
+		"filters.from":            "De :",
+		"filters.to":              "À :",
+		"filters.min":             "Min :",
+		"filters.max":             "Max :",
+		"filters.membersCount":    "Nombre de membres",
+		"filters.locations":       "Locations (pays)",
+		"filters.more":            "Voir plus...",
+		"filters.less":            "Voir moins...",
+		"filters.apply":           "Appliquer les filtres",
+		"filters.invalidCreation": "La date de création minimum ne peut pas être supérieure à la date maximum.",
+		"filters.invalidAlbum":    "L'année de premier album minimum ne peut pas être supérieure à l'année maximum.",
+		"filters.invalidMembers":  "Le nombre de membres minimum ne peut pas être supérieur au nombre maximum.",
+
+		"export.title":    "Exporter",
+		"export.artists":  "Artistes (%s)",
+		"export.concerts": "Concerts (%s)",
+
+		"card.details":       "Voir détails →",
+		"card.members.one":   "%d membre",
+		"card.members.other": "%d membres",
+
+		"noResults.title": "😕 Aucun résultat",
+		"noResults.text":  "Aucun artiste ne correspond à vos critères de recherche ou de filtrage.",
+
+		"artist.members":            "👥 Membres du groupe",
+		"artist.concerts":           "🎤 Concerts et tournées",
+		"artist.mapNote":            "📍 Cliquez sur les marqueurs pour voir les dates des concerts",
+		"artist.mapDates":           "Dates des concerts :",
+		"artist.mapTitle":           "Carte des concerts de %s",
+		"artist.mapMarker":          "%s : %s",
+		"artist.datesLocations":     "Dates et lieux",
+		"artist.subscribe":          "📆 S'abonner au calendrier (.ics)",
+		"artist.noConcerts":         "Aucune date de concert disponible.",
+		"artist.overlaps":           "🎪 Aussi à l'affiche",
+		"artist.sameDay":            "le même jour",
+		"artist.dayBefore":          "la veille",
+		"artist.dayAfter":           "le lendemain",
+		"artist.similar":            "💡 Si vous aimez %s",
+		"artist.description":        "%s, formé en %d",
+		"artist.descriptionMembers": "%s, formé en %d : %s",
+		"artist.firstAlbum":         "Premier album : %s.",
+
+		"similar.created":         "créé en %d",
+		"similar.sameEra":         "même époque",
+		"similar.members.one":     "%d membre",
+		"similar.members.other":   "%d membres",
+		"similar.countries.one":   "%d pays de tournée en commun",
+		"similar.countries.other": "%d pays de tournée en commun",
+		"similar.concerts.one":    "%d concert partagé",
+		"similar.concerts.other":  "%d concerts partagés",
+
+		"stats.title":            "Statistiques",
+		"stats.artists":          "Artistes",
+		"stats.concerts":         "Concerts",
+		"stats.places":           "Lieux",
+		"stats.countries":        "Pays",
+		"stats.perDecade":        "Artistes par décennie de création",
+		"stats.decade":           "années %d",
+		"stats.bandSize":         "Taille moyenne des groupes",
+		"stats.busiestCountries": "Pays les plus visités",
+		"stats.busiestCities":    "Villes les plus visitées",
+		"stats.perYear":          "Concerts par année",
+		"stats.travelled":        "Artistes les plus voyageurs (km)",
+		"stats.creationToAlbum":  "De la création au premier album",
+		"stats.averageGap":       "Écart moyen : %v an(s)",
+		"stats.gap.sameYear":     "même année",
+		"stats.gap.oneYear":      "1 an",
+		"stats.gap.twoThree":     "2-3 ans",
+		"stats.gap.fourFive":     "4-5 ans",
+		"stats.gap.sixPlus":      "6 ans et plus",
+		"stats.noData":           "Aucune donnée disponible.",
+
+		"calendar.title":           "Calendrier des concerts",
+		"calendar.monthView":       "Vue mensuelle",
+		"calendar.yearView":        "Vue annuelle",
+		"calendar.concerts.one":    "%d concert",
+		"calendar.concerts.other":  "%d concerts",
+		"calendar.thisMonth.one":   "%d concert ce mois-ci",
+		"calendar.thisMonth.other": "%d concerts ce mois-ci",
+
+		"location.back":     "← Calendrier",
+		"location.artists":  "🎸 Artistes passés par ici",
+		"location.concerts": "🎤 Concerts",
+
+		"error.title":    "Erreur %d",
+		"error.text.404": "La page que vous recherchez semble avoir disparu dans les coulisses...",
+		"error.text.500": "Notre serveur a fait une fausse note. Nos techniciens sont sur le coup !",
+		"error.text":     "Une erreur inattendue s'est produite.",
+
+		"error.status.400": "La requête est invalide.",
+		"error.status.404": "La page que vous recherchez n'existe pas.",
+		"error.status.405": "Méthode non autorisée.",
+		"error.status.429": "Trop de requêtes, réessayez dans quelques instants.",
+		"error.status.500": "Une erreur interne du serveur s'est produite.",
+
+		"error.methodNotAllowed":   "méthode non autorisée",
+		"error.unknownResource":    "ressource inconnue",
+		"error.invalidArtistID":    "identifiant d'artiste invalide",
+		"error.artistNotFound":     "artiste introuvable",
+		"error.unknownExport":      "export inconnu : %s",
+		"error.tooManyRequests":    "Trop de requêtes, réessayez dans %d s.",
+		"error.variablesNotObject": "variables doit être un objet JSON",
+		"error.expectedJSON":       "Content-Type application/json attendu",
+		"error.invalidJSON":        "corps de requête JSON invalide",
+		"error.missingQuery":       "paramètre query manquant",
+		"error.latRange":           "lat doit être un nombre entre -90 et 90",
+		"error.lonRange":           "lon doit être un nombre entre -180 et 180",
+		"error.unknownLocation":    "location inconnue : %s",
+		"error.missingPosition":    "lat et lon (ou location) sont requis",
+		"error.radiusRange":        "radius_km doit être compris entre 0 et %.0f",
+		"error.dateFormat":         "%s doit être au format AAAA-MM-JJ",
+		"error.dateOrder":          "to doit être postérieur à from",
+		"error.artistParam":        "artist doit être un identifiant numérique",
+	},
+
+	English: {
+		"lang.fr": "FR",
+		"lang.en": "EN",
+
+		"site.footer":   "© 2024 Groupie Tracker - Ynov project",
+		"nav.back":      "← Back",
+		"nav.home":      "Back to home",
+		"nav.calendar":  "📅 Calendar",
+		"nav.stats":     "📊 Statistics",
+		"nav.languages": "Language",
+
+		"month.1":  "January",
+		"month.2":  "February",
+		"month.3":  "March",
+		"month.4":  "April",
+		"month.5":  "May",
+		"month.6":  "June",
+		"month.7":  "July",
+		"month.8":  "August",
+		"month.9":  "September",
+		"month.10": "October",
+		"month.11": "November",
+		"month.12": "December",
+
+		"date.short": "01/02/2006",
+
+		"relative.today":     "today",
+		"relative.tomorrow":  "tomorrow",
+		"relative.yesterday": "yesterday",
+		"relative.future":    "in %s",
+		"relative.past":      "%s ago",
+		"unit.day.one":       "%d day",
+		"unit.day.other":     "%d days",
+		"unit.week.one":      "%d week",
+		"unit.week.other":    "%d weeks",
+		"unit.month.one":     "%d month",
+		"unit.month.other":   "%d months",
+		"unit.year.one":      "%d year",
+		"unit.year.other":    "%d years",
+
+		"weekday.mon": "Mon",
+		"weekday.tue": "Tue",
+		"weekday.wed": "Wed",
+		"weekday.thu": "Thu",
+		"weekday.fri": "Fri",
+		"weekday.sat": "Sat",
+		"weekday.sun": "Sun",
+
+		"label.creationDate": "Creation date",
+		"label.firstAlbum":   "First album",
+		"label.members":      "Members",
+
+		"feed.artists":     "New artists",
+		"feed.concerts":    "New concerts",
+		"feed.newArtist":   "New artist: %s",
+		"feed.artistAdded": "%s was added to Groupie Tracker.",
+		"feed.newConcert":  "New concert by %s in %s on %s.",
+
+		"search.placeholder":   "Search for an artist, member, location...",
+		"search.resultsFor":    "Results for:",
+		"search.results.one":   "%d result",
+		"search.results.other": "%d results",
+
+		"suggestion.artist/band":      "artist/band",
+		"suggestion.member":           "member",
+		"suggestion.creation date":    "creation date",
+		"suggestion.first album date": "first album date",
+
+		"nearby.title":       "📍 Nearby concerts",
+		"nearby.place":       "Place",
+		"nearby.coordinates": "Use the coordinates",
+		"nearby.latitude":    "Latitude",
+		"nearby.longitude":   "Longitude",
+		"nearby.radius":      "Radius (km)",
+		"nearby.from":        "From",
+		"nearby.to":          "To",
+		"nearby.submit":      "Search",
+		"nearby.error":       "The search failed.",
+		"nearby.network":     "Network error.",
+		"nearby.none":        "No concerts within this radius.",
+
+		"filters.title":           "🎛️ Filters",
+		"filters.applied":         "✓ Filters applied",
+		"filters.reset":           "Reset",
+		"filters.from":            "From:",
+		"filters.to":              "To:",
+		"filters.min":             "Min:",
+		"filters.max":             "Max:",
+		"filters.membersCount":    "Number of members",
+		"filters.locations":       "Locations (countries)",
+		"filters.more":            "Show more...",
+		"filters.less":            "Show less...",
+		"filters.apply":           "Apply filters",
+		"filters.invalidCreation": "The minimum creation date cannot be later than the maximum.",
+		"filters.invalidAlbum":    "The minimum first album year cannot be later than the maximum.",
+		"filters.invalidMembers":  "The minimum number of members cannot be greater than the maximum.",
+
+		"export.title":    "Export",
+		"export.artists":  "Artists (%s)",
+		"export.concerts": "Concerts (%s)",
+
+		"card.details":       "View details →",
+		"card.members.one":   "%d member",
+		"card.members.other": "%d members",
+
+		"noResults.title": "😕 No results",
+		"noResults.text":  "No artist matches your search or filters.",
+
+		"artist.members":            "👥 Band members",
+		"artist.concerts":           "🎤 Concerts and tours",
+		"artist.mapNote":            "📍 Click the markers to see the concert dates",
+		"artist.mapDates":           "Concert dates:",
+		"artist.mapTitle":           "Concert map for %s",
+		"artist.mapMarker":          "%s: %s",
+		"artist.datesLocations":     "Dates and places",
+		"artist.subscribe":          "📆 Subscribe to the calendar (.ics)",
+		"artist.noConcerts":         "No concert dates available.",
+		"artist.overlaps":           "🎪 Also on the bill",
+		"artist.sameDay":            "the same day",
+		"artist.dayBefore":          "the day before",
+		"artist.dayAfter":           "the day after",
+		"artist.similar":            "💡 If you like %s",
+		"artist.description":        "%s, formed in %d",
+		"artist.descriptionMembers": "%s, formed in %d: %s",
+		"artist.firstAlbum":         "First album: %s.",
+
+		"similar.created":         "formed in %d",
+		"similar.sameEra":         "same era",
+		"similar.members.one":     "%d member",
+		"similar.members.other":   "%d members",
+		"similar.countries.one":   "%d shared tour country",
+		"similar.countries.other": "%d shared tour countries",
+		"similar.concerts.one":    "%d shared concert",
+		"similar.concerts.other":  "%d shared concerts",
+
+		"stats.title":            "Statistics",
+		"stats.artists":          "Artists",
+		"stats.concerts":         "Concerts",
+		"stats.places":           "Places",
+		"stats.countries":        "Countries",
+		"stats.perDecade":        "Artists by decade of creation",
+		"stats.decade":           "%ds",
+		"stats.bandSize":         "Average band size",
+		"stats.busiestCountries": "Most visited countries",
+		"stats.busiestCities":    "Most visited cities",
+		"stats.perYear":          "Concerts per year",
+		"stats.travelled":        "Most travelled artists (km)",
+		"stats.creationToAlbum":  "From creation to first album",
+		"stats.averageGap":       "Average gap: %v year(s)",
+		"stats.gap.sameYear":     "same year",
+		"stats.gap.oneYear":      "1 year",
+		"stats.gap.twoThree":     "2-3 years",
+		"stats.gap.fourFive":     "4-5 years",
+		"stats.gap.sixPlus":      "6 years or more",
+		"stats.noData":           "No data available.",
+
+		"calendar.title":           "Concert calendar",
+		"calendar.monthView":       "Month view",
+		"calendar.yearView":        "Year view",
+		"calendar.concerts.one":    "%d concert",
+		"calendar.concerts.other":  "%d concerts",
+		"calendar.thisMonth.one":   "%d concert this month",
+		"calendar.thisMonth.other": "%d concerts this month",
+
+		"location.back":     "← Calendar",
+		"location.artists":  "🎸 Artists who played here",
+		"location.concerts": "🎤 Concerts",
+
+		"error.title":    "Error %d",
+		"error.text.404": "The page you are looking for seems to have vanished backstage...",
+		"error.text.500": "Our server hit a wrong note. Our technicians are on it!",
+		"error.text":     "An unexpected error occurred.",
+
+		"error.status.400": "The request is invalid.",
+		"error.status.404": "The page you are looking for does not exist.",
+		"error.status.405": "Method not allowed.",
+		"error.status.429": "Too many requests, please try again shortly.",
+		"error.status.500": "An internal server error occurred.",
+
+		"error.methodNotAllowed":   "method not allowed",
+		"error.unknownResource":    "unknown resource",
+		"error.invalidArtistID":    "invalid artist ID",
+		"error.artistNotFound":     "artist not found",
+		"error.unknownExport":      "unknown export: %s",
+		"error.tooManyRequests":    "Too many requests, try again in %d s.",
+		"error.variablesNotObject": "variables must be a JSON object",
+		"error.expectedJSON":       "expected Content-Type application/json",
+		"error.invalidJSON":        "invalid JSON request body",
+		"error.missingQuery":       "missing query parameter",
+		"error.latRange":           "lat must be a number between -90 and 90",
+		"error.lonRange":           "lon must be a number between -180 and 180",
+		"error.unknownLocation":    "unknown location: %s",
+		"error.missingPosition":    "lat and lon (or location) are required",
+		"error.radiusRange":        "radius_km must be between 0 and %.0f",
+		"error.dateFormat":         "%s must use the YYYY-MM-DD format",
+		"error.dateOrder":          "to must not be before from",
+		"error.artistParam":        "artist must be a numeric identifier",
+	},
+}
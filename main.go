@@ -1,32 +1,188 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 	"api-groupie-tracker/api"
+	"api-groupie-tracker/config"
 	"api-groupie-tracker/handlers"
 )
 
+// routes liste les pages et endpoints de l'application. Les routes /api/
+// doivent être décrites dans la spécification OpenAPI (voir main_test.go).
+var routes = []struct {
+	pattern string
+	handler http.HandlerFunc
+}{
+	{"/", handlers.HomeHandler},
+	{"/artist/", handlers.ArtistHandler},
+	{"/search", handlers.RateLimited(handlers.SearchLimit, handlers.SearchHandler)},
+	{"/filter", handlers.FilterHandler},
+	{"/stats", handlers.StatsHandler},
+	{"/calendar", handlers.CalendarHandler},
+	{"/location/", handlers.LocationHandler},
+	{"/feeds/", handlers.FeedsHandler},
+	{"/sitemap.xml", handlers.SitemapHandler},
+	{"/robots.txt", handlers.RobotsHandler},
+	{"/metrics", handlers.MetricsHandler},
+	{"/healthz", handlers.HealthHandler},
+	{"/readyz", handlers.ReadyHandler},
+	{"/graphql", handlers.GraphQLHandler},
+	{"/api/openapi.json", handlers.OpenAPIHandler},
+	{"/api/suggestions", handlers.RateLimited(handlers.SuggestionsLimit, handlers.SuggestionsHandler)},
+	{"/api/v1/concerts/nearby", handlers.NearbyConcertsHandler},
+	{"/api/v1/artists/", handlers.ArtistAPIHandler},
+	{"/api/v1/stats", handlers.StatsAPIHandler},
+	{"/api/v1/concerts.ics", handlers.ConcertsICSHandler},
+	{"/api/v1/export/", handlers.ExportHandler},
+}
+
+// Limites de la lecture des en-têtes d'une requête
+const (
+	readHeaderTimeout = 5 * time.Second
+	maxHeaderBytes    = 64 << 10
+)
+
 func main() {
-	// Charger les données de l'API au démarrage
-	if err := api.FetchAllData(); err != nil {
-		log.Fatal("Erreur lors du chargement des données de l'API:", err)
-	}
-
-	// Configuration des routes
-	http.HandleFunc("/", handlers.HomeHandler)
-	http.HandleFunc("/artist/", handlers.ArtistHandler)
-	http.HandleFunc("/search", handlers.SearchHandler)
-	http.HandleFunc("/filter", handlers.FilterHandler)
-	http.HandleFunc("/api/suggestions", handlers.SuggestionsHandler)
-	
-	// Servir les fichiers statiques
-	fs := http.FileServer(http.Dir("static"))
-	http.Handle("/static/", http.StripPrefix("/static/", fs))
+	cfg, err := config.Load(os.Args[1:], os.Getenv, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Configuration invalide:\n"+err.Error())
+		os.Exit(2)
+	}
+
+	// Journaux structurés ; le paquet log y est aussi redirigé
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	// SIGINT (Ctrl+C) et SIGTERM déclenchent l'arrêt propre du serveur
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	api.Configure(cfg.UpstreamBaseURL, cfg.UpstreamTimeout, cfg.SnapshotPath)
+	templateFS, staticFS, err := assetDirs(cfg)
+	if err != nil {
+		log.Fatal("Erreur lors de l'accès aux fichiers embarqués:", err)
+	}
+	if err := handlers.UseTemplates(templateFS, cfg.Dev); err != nil {
+		log.Fatal("Erreur lors du chargement des templates:", err)
+	}
+	handlers.SetMaxDataAge(cfg.MaxDataAge)
+	handlers.SetTrustedProxyHeader(cfg.TrustedProxyHeader)
+	handlers.SetPublicURL(cfg.PublicURL)
+	handlers.SetGoogleMapsKey(cfg.GoogleMapsKey)
+	if cfg.Dev {
+		log.Printf("Mode développement : templates relus depuis %s, fichiers statiques depuis %s", cfg.TemplateDir, cfg.StaticDir)
+	}
+
+	// Charger les données de l'API au démarrage, ou à défaut la sauvegarde
+	if err := api.FetchAllData(ctx); err != nil {
+		if cfg.SnapshotPath == "" {
+			log.Fatal("Erreur lors du chargement des données de l'API:", err)
+		}
+		log.Println("API indisponible, chargement de la sauvegarde:", err)
+		if err := api.LoadSnapshot(cfg.SnapshotPath); err != nil {
+			log.Fatal("Erreur lors du chargement de la sauvegarde:", err)
+		}
+	}
+
+	// Rafraîchir les données en arrière-plan jusqu'à l'arrêt
+	var refresher sync.WaitGroup
+	if cfg.RefreshInterval > 0 {
+		refresher.Add(1)
+		go func() {
+			defer refresher.Done()
+			api.RunRefresher(ctx, cfg.RefreshInterval)
+		}()
+	}
+
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		log.Fatal("Erreur lors de l'ouverture du port:", err)
+	}
 
 	// Démarrer le serveur
-	port := ":8080"
-	fmt.Printf("🎵 Groupie Tracker démarré sur http://localhost%s\n", port)
-	log.Fatal(http.ListenAndServe(port, nil))
-}
\ No newline at end of file
+	address := "http://" + cfg.Addr
+	if strings.HasPrefix(cfg.Addr, ":") {
+		address = "http://localhost" + cfg.Addr
+	}
+	fmt.Printf("🎵 Groupie Tracker démarré sur %s\n", address)
+
+	mux := newMux(staticFS)
+	handler := handlers.Chain(mux, handlers.RequestID, handlers.AccessLog, handlers.Recover,
+		handlers.Metrics(mux), handlers.Compress, handlers.Language, handlers.Cache)
+	err = serve(ctx, newServer(cfg, handler), ln, cfg.ShutdownTimeout)
+
+	stop()
+	refresher.Wait()
+	if err != nil {
+		log.Fatal("Erreur du serveur:", err)
+	}
+	log.Println("Serveur arrêté")
+}
+
+// newMux enregistre les routes et les fichiers statiques
+func newMux(static fs.FS) *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, route := range routes {
+		mux.HandleFunc(route.pattern, route.handler)
+	}
+
+	files := http.FileServer(http.FS(static))
+	mux.Handle("/static/", http.StripPrefix("/static/", files))
+	return mux
+}
+
+// newServer applique les délais et limites de la configuration
+func newServer(cfg config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    maxHeaderBytes,
+	}
+}
+
+// serve sert les requêtes sur ln jusqu'à l'annulation de ctx, puis ferme le
+// port et laisse aux requêtes en cours jusqu'à timeout pour se terminer
+func serve(ctx context.Context, server *http.Server, ln net.Listener, timeout time.Duration) error {
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.Serve(ln)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("Arrêt du serveur, fin des requêtes en cours...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("arrêt incomplet: %w", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api-groupie-tracker/config"
+	"api-groupie-tracker/handlers"
+)
+
+// prefixRoutePaths liste les chemins servis par chaque route /api/ se
+// terminant par "/", telle qu'écrits dans la spécification OpenAPI
+var prefixRoutePaths = map[string][]string{
+	"/api/v1/artists/": {
+		"/api/v1/artists/{id}/overlaps",
+		"/api/v1/artists/{id}/map",
+		"/api/v1/artists/{id}/similar",
+	},
+	"/api/v1/export/": {"/api/v1/export/{file}"},
+}
+
+// Chaque route /api/ enregistrée doit être décrite par la spécification
+// OpenAPI, et chaque chemin de la spécification doit être servi
+func TestOpenAPICoversRoutes(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handlers.OpenAPIHandler(rec, httptest.NewRequest("GET", "/api/openapi.json", nil))
+	if rec.Code != 200 {
+		t.Fatalf("GET /api/openapi.json = %d; expected 200", rec.Code)
+	}
+
+	var spec struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("invalid OpenAPI JSON: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("openapi = %q; expected 3.x", spec.OpenAPI)
+	}
+
+	for _, route := range routes {
+		if !strings.HasPrefix(route.pattern, "/api/") {
+			continue
+		}
+
+		paths := []string{route.pattern}
+		if strings.HasSuffix(route.pattern, "/") {
+			paths = prefixRoutePaths[route.pattern]
+			if len(paths) == 0 {
+				t.Errorf("prefix route %s: list the paths it serves in prefixRoutePaths", route.pattern)
+			}
+		}
+		for _, path := range paths {
+			if _, ok := spec.Paths[path]; !ok {
+				t.Errorf("path %s (route %s) is missing from the OpenAPI specification", path, route.pattern)
+			}
+		}
+	}
+
+	served := make(map[string]bool)
+	for _, route := range routes {
+		served[route.pattern] = true
+	}
+	for _, paths := range prefixRoutePaths {
+		for _, path := range paths {
+			served[path] = true
+		}
+	}
+	for path := range spec.Paths {
+		if !served[path] {
+			t.Errorf("OpenAPI path %s has no registered route", path)
+		}
+	}
+}
+
+// L'annulation du contexte ferme le port mais laisse la requête en cours
+// se terminer avant que serve ne retourne
+func TestServeShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "terminé")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(ctx, newServer(config.Default(), mux), ln, 5*time.Second)
+	}()
+
+	type response struct {
+		body string
+		err  error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/slow")
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- response{string(body), err}
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		t.Fatalf("serve returned before the in-flight request finished: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Les nouvelles connexions sont refusées pendant l'arrêt
+	if conn, err := net.DialTimeout("tcp", ln.Addr().String(), time.Second); err == nil {
+		conn.Close()
+		t.Error("listener still accepts connections after shutdown started")
+	}
+
+	close(release)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("serve() = %v; expected nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after shutdown")
+	}
+
+	if r := <-responses; r.err != nil || r.body != "terminé" {
+		t.Errorf("in-flight request = (%q, %v); expected (\"terminé\", nil)", r.body, r.err)
+	}
+}
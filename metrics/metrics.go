@@ -0,0 +1,281 @@
+// Package metrics expose des compteurs, histogrammes et jauges au format
+// texte de Prometheus, sans dépendance externe
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets sont les bornes (en secondes) des histogrammes de durée
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Collector est une métrique capable de s'écrire au format texte
+type Collector interface {
+	Name() string
+	writeText(w io.Writer)
+}
+
+// Registry regroupe les métriques exposées par un même endpoint
+type Registry struct {
+	mu         sync.Mutex
+	collectors map[string]Collector
+}
+
+// NewRegistry crée un registre vide
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+// Default est le registre exposé sur /metrics
+var Default = NewRegistry()
+
+// Register ajoute c au registre. Deux métriques ne peuvent pas porter le
+// même nom.
+func (reg *Registry) Register(c Collector) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if _, ok := reg.collectors[c.Name()]; ok {
+		panic("metrics: métrique déjà enregistrée : " + c.Name())
+	}
+	reg.collectors[c.Name()] = c
+}
+
+// WriteText écrit toutes les métriques, triées par nom
+func (reg *Registry) WriteText(w io.Writer) {
+	reg.mu.Lock()
+	collectors := make([]Collector, 0, len(reg.collectors))
+	for _, c := range reg.collectors {
+		collectors = append(collectors, c)
+	}
+	reg.mu.Unlock()
+
+	sort.Slice(collectors, func(i, j int) bool {
+		return collectors[i].Name() < collectors[j].Name()
+	})
+	for _, c := range collectors {
+		c.writeText(w)
+	}
+}
+
+// NewCounter crée un compteur enregistré dans Default
+func NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{desc: desc{name, help, labels}, series: make(map[string]*counterSeries)}
+	Default.Register(c)
+	return c
+}
+
+// NewHistogram crée un histogramme enregistré dans Default
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{desc: desc{name, help, labels}, buckets: buckets, series: make(map[string]*histogramSeries)}
+	Default.Register(h)
+	return h
+}
+
+// NewGaugeFunc crée une jauge enregistrée dans Default, dont la valeur est
+// calculée par fn à chaque lecture
+func NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{desc: desc{name: name, help: help}, fn: fn}
+	Default.Register(g)
+	return g
+}
+
+/*
+	desc
+	➡️ nom, description et noms des labels d'une métrique
+*/
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (d desc) Name() string {
+	return d.name
+}
+
+func (d desc) writeHeader(w io.Writer, kind string) {
+	help := strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(d.help)
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, help, d.name, kind)
+}
+
+// key identifie une série par ses valeurs de labels
+func (d desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s attend %d labels, reçu %d", d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// labelText formate {a="x",b="y"}, complété des paires extra
+func (d desc) labelText(values []string, extra ...string) string {
+	if len(values) == 0 && len(extra) == 0 {
+		return ""
+	}
+
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, 0, len(values)+len(extra)/2)
+	for i, value := range values {
+		pairs = append(pairs, d.labels[i]+`="`+escape.Replace(value)+`"`)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escape.Replace(extra[i+1])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// =======================
+// COUNTER
+// =======================
+
+// Counter est une valeur qui ne fait que croître, par combinaison de labels
+type Counter struct {
+	desc
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	values []string
+	value  float64
+}
+
+// Inc ajoute 1 à la série désignée par les valeurs de labels
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add ajoute v (positif) à la série désignée par les valeurs de labels
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		panic("metrics: un compteur ne peut pas décroître")
+	}
+	key := c.key(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.series[key]
+	if !ok {
+		s = &counterSeries{values: append([]string(nil), labelValues...)}
+		c.series[key] = s
+	}
+	s.value += v
+}
+
+func (c *Counter) writeText(w io.Writer) {
+	c.writeHeader(w, "counter")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.series))
+	for key := range c.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := c.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelText(s.values), formatFloat(s.value))
+	}
+}
+
+// =======================
+// HISTOGRAM
+// =======================
+
+// Histogram répartit des observations (des durées le plus souvent) dans des
+// intervalles cumulés
+type Histogram struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	values []string
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Observe enregistre v dans la série désignée par les valeurs de labels
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{values: append([]string(nil), labelValues...), counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			s.counts[i]++
+			break
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *Histogram) writeText(w io.Writer) {
+	h.writeHeader(w, "histogram")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := h.series[key]
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelText(s.values, "le", formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelText(s.values, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelText(s.values), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelText(s.values), s.count)
+	}
+}
+
+// =======================
+// GAUGE
+// =======================
+
+// GaugeFunc est une valeur instantanée, lue au moment de l'export
+type GaugeFunc struct {
+	desc
+	fn func() float64
+}
+
+func (g *GaugeFunc) writeText(w io.Writer) {
+	g.writeHeader(w, "gauge")
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.fn()))
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+// useRegistry remplace Default par un registre vide le temps du test
+func useRegistry(t *testing.T) {
+	previous := Default
+	Default = NewRegistry()
+	t.Cleanup(func() { Default = previous })
+}
+
+func TestWriteText(t *testing.T) {
+	useRegistry(t)
+
+	requests := NewCounter("test_requests_total", "Requêtes.", "route", "status")
+	requests.Inc("/artist/", "200")
+	requests.Inc("/artist/", "200")
+	requests.Add(3, "/", `4"04`)
+
+	duration := NewHistogram("test_duration_seconds", "Durée.", []float64{0.1, 1}, "route")
+	duration.Observe(0.05, "/")
+	duration.Observe(0.5, "/")
+	duration.Observe(2, "/")
+
+	NewGaugeFunc("test_artists", "Artistes\nchargés.", func() float64 { return 52 })
+
+	var out strings.Builder
+	Default.WriteText(&out)
+
+	expected := `# HELP test_artists Artistes\nchargés.
+# TYPE test_artists gauge
+test_artists 52
+# HELP test_duration_seconds Durée.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{route="/",le="0.1"} 1
+test_duration_seconds_bucket{route="/",le="1"} 2
+test_duration_seconds_bucket{route="/",le="+Inf"} 3
+test_duration_seconds_sum{route="/"} 2.55
+test_duration_seconds_count{route="/"} 3
+# HELP test_requests_total Requêtes.
+# TYPE test_requests_total counter
+test_requests_total{route="/artist/",status="200"} 2
+test_requests_total{route="/",status="4\"04"} 3
+`
+	if out.String() != expected {
+		t.Errorf("WriteText() =\n%s\nexpected:\n%s", out.String(), expected)
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	useRegistry(t)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a metric twice did not panic")
+		}
+	}()
+	NewCounter("test_duplicate_total", "Doublon.")
+	NewCounter("test_duplicate_total", "Doublon.")
+}
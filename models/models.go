@@ -1,5 +1,37 @@
 package models
 
+import "time"
+
+// Format ISO 8601 des dates sans heure dans les réponses JSON
+const ISODateLayout = "2006-01-02"
+
+// LocalDate est une date sans heure (premier album, jour de concert),
+// encodée en JSON au format ISO "2006-01-02" pour rester triable. La date
+// zéro est encodée null.
+type LocalDate struct {
+	time.Time
+}
+
+func (d LocalDate) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.Format(ISODateLayout) + `"`), nil
+}
+
+func (d *LocalDate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		d.Time = time.Time{}
+		return nil
+	}
+	t, err := time.Parse(`"`+ISODateLayout+`"`, string(data))
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
 // Artist représente un artiste/groupe
 type Artist struct {
 	ID           int      `json:"id"`
@@ -71,5 +103,162 @@ type FilterCriteria struct {
 type SearchSuggestion struct {
 	Value string `json:"value"`
 	Type  string `json:"type"`
+	Label string `json:"label"` // Type traduit, pour l'affichage
 	ID    int    `json:"id"`
-}
\ No newline at end of file
+}
+
+// Concert représente un concert : un artiste, une location et une date
+type Concert struct {
+	ArtistID   int       `json:"artistId"`
+	ArtistName string    `json:"artistName"`
+	Location   string    `json:"location"`
+	Date       time.Time `json:"date"`
+}
+
+// NearbyCriteria représente les critères de la recherche de concerts à proximité
+type NearbyCriteria struct {
+	Lat      float64
+	Lon      float64
+	RadiusKm float64
+	From     time.Time
+	To       time.Time
+}
+
+// ConcertCriteria représente les critères de filtrage des concerts
+type ConcertCriteria struct {
+	ArtistIDs []int
+	Location  string
+	Country   string
+	From      time.Time
+	To        time.Time
+}
+
+// NearbyConcert est un concert trouvé par la recherche géographique
+type NearbyConcert struct {
+	Concert
+	Lat         float64 `json:"lat"`
+	Lon         float64 `json:"lon"`
+	DistanceKm  float64 `json:"distanceKm"`
+	Approximate bool    `json:"approximate"`
+}
+
+// MapPlace est un lieu de concert d'un artiste, avec sa position et ses
+// dates, pour la carte de la page artiste. Located vaut false si le lieu est
+// absent du répertoire géographique ; Approximate, si seul le pays est connu.
+type MapPlace struct {
+	Location    string      `json:"location"`
+	Name        string      `json:"name"`
+	Lat         float64     `json:"lat"`
+	Lon         float64     `json:"lon"`
+	Located     bool        `json:"located"`
+	Approximate bool        `json:"approximate"`
+	Dates       []LocalDate `json:"dates"`
+}
+
+// Overlap signale qu'un autre artiste a joué dans la même location
+// le même jour ou à un jour d'écart (festival, co-plateau)
+type Overlap struct {
+	Location        string    `json:"location"`
+	Date            time.Time `json:"date"`
+	OtherArtistID   int       `json:"otherArtistId"`
+	OtherArtistName string    `json:"otherArtistName"`
+	OtherDate       time.Time `json:"otherDate"`
+	DaysApart       int       `json:"daysApart"`
+}
+
+// SimilarArtist est une recommandation "si vous aimez X"
+type SimilarArtist struct {
+	ID      int             `json:"id"`
+	Name    string          `json:"name"`
+	Image   string          `json:"image"`
+	Score   float64         `json:"score"`
+	Reasons []SimilarReason `json:"reasons"`
+}
+
+// SimilarReason est un point commun entre deux artistes : une clé du
+// catalogue i18n ("similar.members") et sa valeur. Text est rempli à
+// l'affichage, dans la langue de la requête.
+type SimilarReason struct {
+	Key   string `json:"key"`
+	Value int    `json:"value"`
+	Text  string `json:"text"`
+}
+
+// StatBar est une ligne d'un graphique en barres du tableau de bord. Un
+// libellé à traduire est donné par Key, une clé du catalogue i18n formatée
+// avec Args ; Label est alors rempli à l'affichage.
+type StatBar struct {
+	Label   string        `json:"label"`
+	Key     string        `json:"-"`
+	Args    []interface{} `json:"-"`
+	Value   float64       `json:"value"`
+	Percent int           `json:"-"`
+}
+
+// Stats regroupe les statistiques agrégées du jeu de données
+type Stats struct {
+	ArtistCount   int `json:"artistCount"`
+	ConcertCount  int `json:"concertCount"`
+	LocationCount int `json:"locationCount"`
+	CountryCount  int `json:"countryCount"`
+
+	ArtistsPerDecade []StatBar `json:"artistsPerDecade"`
+	BandSizeByDecade []StatBar `json:"bandSizeByDecade"`
+	BusiestCountries []StatBar `json:"busiestCountries"`
+	BusiestCities    []StatBar `json:"busiestCities"`
+	ConcertsPerYear  []StatBar `json:"concertsPerYear"`
+	MostTravelled    []StatBar `json:"mostTravelledKm"`
+
+	CreationToAlbum        []StatBar `json:"creationToAlbum"`
+	AverageCreationToAlbum float64   `json:"averageCreationToAlbumYears"`
+}
+
+// CalendarDay est une case du calendrier des concerts
+type CalendarDay struct {
+	Date     time.Time
+	InMonth  bool
+	Concerts []Concert
+}
+
+// CalendarMonth est la grille d'un mois (semaines du lundi au dimanche)
+type CalendarMonth struct {
+	Year     int
+	Month    time.Month
+	Name     string
+	Weeks    [][]CalendarDay
+	Concerts int
+}
+
+// FeedEntry est une nouveauté détectée entre deux chargements des données
+type FeedEntry struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"` // "artist" ou "concert"
+	ArtistID   int       `json:"artistId"`
+	ArtistName string    `json:"artistName"`
+	Location   string    `json:"location,omitempty"`
+	Date       time.Time `json:"date,omitempty"`
+	Countries  []string  `json:"countries"`
+	Added      time.Time `json:"added"`
+}
+
+// UpstreamStatus est l'état des appels à une ressource de l'API
+type UpstreamStatus struct {
+	Endpoint    string     `json:"endpoint"`
+	OK          bool       `json:"ok"`
+	LastAttempt *time.Time `json:"lastAttempt,omitempty"`
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+}
+
+// DataState décrit l'origine du jeu de données courant et l'état des
+// sources dont il provient
+type DataState struct {
+	Source      string           `json:"source"` // "api", "snapshot" ou "" avant le premier chargement
+	LastRefresh time.Time        `json:"lastRefresh"`
+	Artists     int              `json:"artists"`
+	Concerts    int              `json:"concerts"`
+	Upstream    []UpstreamStatus `json:"upstream"`
+
+	SnapshotPath    string    `json:"snapshotPath,omitempty"`
+	SnapshotSavedAt time.Time `json:"snapshotSavedAt"`
+}
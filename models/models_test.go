@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLocalDateJSON(t *testing.T) {
+	v := struct {
+		Date  LocalDate `json:"date"`
+		Empty LocalDate `json:"empty"`
+	}{Date: LocalDate{time.Date(2019, time.August, 21, 0, 0, 0, 0, time.UTC)}}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"date":"2019-08-21","empty":null}` {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	var decoded LocalDate
+	if err := json.Unmarshal([]byte(`"2019-08-21"`), &decoded); err != nil || !decoded.Equal(v.Date.Time) {
+		t.Errorf("Unmarshal = %v, %v; expected 2019-08-21", decoded, err)
+	}
+	if err := json.Unmarshal([]byte(`"21-08-2019"`), &decoded); err == nil {
+		t.Error("expected an error for a non-ISO date")
+	}
+}
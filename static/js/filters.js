@@ -13,7 +13,7 @@ if (showMoreLocationsBtn && checkboxFilter) {
             allLabels.forEach(label => {
                 label.style.display = 'block';
             });
-            showMoreLocationsBtn.textContent = 'Voir moins...';
+            showMoreLocationsBtn.textContent = showMoreLocationsBtn.dataset.less;
             expanded = true;
         } else {
             // Masquer les locations après les 10 premières
@@ -22,7 +22,7 @@ if (showMoreLocationsBtn && checkboxFilter) {
                     label.style.display = 'none';
                 }
             });
-            showMoreLocationsBtn.textContent = 'Voir plus...';
+            showMoreLocationsBtn.textContent = showMoreLocationsBtn.dataset.more;
             expanded = false;
         }
     });
@@ -50,19 +50,19 @@ if (filterForm) {
         // Validation des plages
         if (creationMin > creationMax) {
             e.preventDefault();
-            alert('La date de création minimum ne peut pas être supérieure à la date maximum.');
+            alert(filterForm.dataset.invalidCreation);
             return;
         }
 
         if (albumMin > albumMax) {
             e.preventDefault();
-            alert('L\'année de premier album minimum ne peut pas être supérieure à l\'année maximum.');
+            alert(filterForm.dataset.invalidAlbum);
             return;
         }
 
         if (membersMin > membersMax) {
             e.preventDefault();
-            alert('Le nombre de membres minimum ne peut pas être supérieur au nombre maximum.');
+            alert(filterForm.dataset.invalidMembers);
             return;
         }
     });
@@ -3,12 +3,19 @@ let map;
 let markers = [];
 let geocoder;
 
+// Textes traduits, fournis par le template dans les attributs data-*
+const mapElement = document.getElementById('map');
+
+// Lieux de concert de l'artiste, sérialisés par le serveur (MapResponse)
+const mapDataElement = document.getElementById('artist-map');
+const artistMap = mapDataElement ? JSON.parse(mapDataElement.textContent) : null;
+
 
 function initMap() {
    
     const defaultCenter = { lat: 48.8566, lng: 2.3522 };
 
-    map = new google.maps.Map(document.getElementById('map'), {
+    map = new google.maps.Map(mapElement, {
         zoom: 2,
         center: defaultCenter,
         styles: [
@@ -34,26 +41,29 @@ function initMap() {
 
     geocoder = new google.maps.Geocoder();
 
-    // Géocoder et afficher tous les lieux de concert
-    if (typeof artistData !== 'undefined' && artistData.locations) {
-        geocodeLocations(artistData.locations);
+    // Afficher tous les lieux de concert
+    if (artistMap && artistMap.places) {
+        placeMarkers(artistMap.places);
     }
 }
 
 
-async function geocodeLocations(locations) {
+async function placeMarkers(places) {
     const bounds = new google.maps.LatLngBounds();
 
-    for (const locationData of locations) {
+    for (const place of places) {
         try {
-            const position = await geocodeAddress(locationData.location);
-            
+            // Coordonnées fournies par le serveur, sinon géocodage Google
+            const position = place.located
+                ? new google.maps.LatLng(place.lat, place.lon)
+                : await geocodeAddress(place.location);
+
             if (position) {
-                addMarker(position, locationData);
+                addMarker(position, place);
                 bounds.extend(position);
             }
         } catch (error) {
-            console.error(`Erreur de géocodage pour ${locationData.location}:`, error);
+            console.error(`Erreur de géocodage pour ${place.location}:`, error);
         }
     }
 
@@ -80,28 +90,38 @@ function geocodeAddress(address) {
 }
 
 // Ajouter un marqueur sur la carte
-function addMarker(position, locationData) {
+function addMarker(position, place) {
     const marker = new google.maps.Marker({
         position: position,
         map: map,
-        title: locationData.location.replace(/_/g, ' ').replace(/-/g, ' '),
+        title: place.name,
         animation: google.maps.Animation.DROP
     });
 
+    // Contenu construit avec textContent : un nom de lieu n'est jamais interprété comme du HTML
+    const content = document.createElement('div');
+    content.style.cssText = 'color: #0f172a; padding: 10px; max-width: 300px;';
+
+    const title = document.createElement('h3');
+    title.style.cssText = 'margin-top: 0; color: #6366f1;';
+    title.textContent = `📍 ${place.name}`;
+
+    const heading = document.createElement('h4');
+    heading.style.cssText = 'margin-top: 10px; margin-bottom: 5px;';
+    heading.textContent = mapElement.dataset.dates;
+
+    const list = document.createElement('ul');
+    list.style.cssText = 'margin: 5px 0; padding-left: 20px;';
+    place.dates.forEach(date => {
+        const item = document.createElement('li');
+        item.textContent = new Date(date).toLocaleDateString(document.documentElement.lang, { timeZone: 'UTC' });
+        list.appendChild(item);
+    });
 
-    const dates = locationData.dates.map(date => `<li>${date}</li>`).join('');
-    const contentString = `
-        <div style="color: #0f172a; padding: 10px; max-width: 300px;">
-            <h3 style="margin-top: 0; color: #6366f1;">📍 ${marker.title}</h3>
-            <h4 style="margin-top: 10px; margin-bottom: 5px;">Dates des concerts:</h4>
-            <ul style="margin: 5px 0; padding-left: 20px;">
-                ${dates}
-            </ul>
-        </div>
-    `;
+    content.append(title, heading, list);
 
     const infowindow = new google.maps.InfoWindow({
-        content: contentString
+        content: content
     });
 
 
@@ -120,18 +140,10 @@ function addMarker(position, locationData) {
 }
 
 
-if (!document.getElementById('map')) {
+// Sans Google Maps (pas de clé, hors ligne), la carte SVG rendue par le
+// serveur reste affichée
+if (!mapElement) {
     console.log('Carte désactivée - élément non trouvé');
-} else if (typeof google === 'undefined') {
-    document.getElementById('map').innerHTML = `
-        <div style="display: flex; align-items: center; justify-content: center; height: 100%; background: var(--background); color: var(--text-secondary); text-align: center; padding: 2rem;">
-            <div>
-                <p style="font-size: 1.2rem; margin-bottom: 1rem;">🗺️ Carte non disponible</p>
-                <p style="font-size: 0.9rem;">Pour activer la géolocalisation, vous devez configurer une clé API Google Maps.</p>
-                <p style="font-size: 0.8rem; margin-top: 1rem;">Les lieux de concerts sont listés ci-dessous.</p>
-            </div>
-        </div>
-    `;
 }
 
 
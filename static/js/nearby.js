@@ -0,0 +1,67 @@
+// Recherche de concerts à proximité
+const nearbyForm = document.getElementById('nearby-form');
+const nearbyResults = document.getElementById('nearby-results');
+
+if (nearbyForm && nearbyResults) {
+    nearbyForm.addEventListener('submit', async function(e) {
+        e.preventDefault();
+
+        // Ne transmettre que les champs renseignés
+        const params = new URLSearchParams();
+        new FormData(nearbyForm).forEach((value, key) => {
+            if (value !== '') {
+                params.append(key, value);
+            }
+        });
+
+        try {
+            const response = await fetch(`${nearbyForm.action}?${params}`);
+            const data = await response.json();
+
+            if (!response.ok) {
+                showNearbyMessage(data.detail || nearbyResults.dataset.error);
+                return;
+            }
+            displayNearby(data.concerts);
+        } catch (error) {
+            console.error('Erreur lors de la recherche de concerts:', error);
+            showNearbyMessage(nearbyResults.dataset.network);
+        }
+    });
+}
+
+function displayNearby(concerts) {
+    if (!concerts || concerts.length === 0) {
+        showNearbyMessage(nearbyResults.dataset.none);
+        return;
+    }
+
+    nearbyResults.innerHTML = '';
+
+    concerts.forEach(concert => {
+        const item = document.createElement('div');
+        item.className = 'nearby-item';
+
+        const link = document.createElement('a');
+        link.href = `/artist/${concert.artistId}`;
+        link.textContent = concert.artistName;
+
+        const meta = document.createElement('span');
+        meta.className = 'nearby-meta';
+        const date = new Date(concert.date).toLocaleDateString(document.documentElement.lang, { timeZone: 'UTC' });
+        const distance = Math.round(concert.distanceKm);
+        meta.textContent = `${concert.location.replace(/_/g, ' ').replace(/-/g, ' ')} · ${date} · ${concert.approximate ? '~' : ''}${distance} km`;
+
+        item.appendChild(link);
+        item.appendChild(meta);
+        nearbyResults.appendChild(item);
+    });
+}
+
+function showNearbyMessage(message) {
+    nearbyResults.innerHTML = '';
+    const p = document.createElement('p');
+    p.className = 'no-concerts';
+    p.textContent = message;
+    nearbyResults.appendChild(p);
+}
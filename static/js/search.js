@@ -65,6 +65,8 @@ if (searchInput) {
 async function fetchSuggestions(query) {
     try {
         const response = await fetch(`/api/suggestions?q=${encodeURIComponent(query)}`);
+        // Limite atteinte : garder les suggestions affichées, la frappe suivante réessaiera
+        if (response.status === 429) return;
         if (!response.ok) throw new Error('Erreur réseau');
 
         const suggestions = await response.json();
@@ -88,7 +90,7 @@ function displaySuggestions(suggestions) {
         item.className = 'suggestion-item';
         item.innerHTML = `
             <span class="suggestion-value">${highlightMatch(suggestion.value, searchInput.value)}</span>
-            <span class="suggestion-type">${suggestion.type}</span>
+            <span class="suggestion-type">${suggestion.label || suggestion.type}</span>
         `;
 
         item.addEventListener('click', function() {
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"api-groupie-tracker/i18n"
+	"api-groupie-tracker/models"
+)
+
+// MonthName retourne le nom d'un mois dans la langue par défaut
+func MonthName(month time.Month) string {
+	return i18n.MonthName(i18n.Default, month)
+}
+
+// BuildCalendarMonth construit la grille d'un mois, semaines commençant le
+// lundi, avec les concerts de chaque jour
+func BuildCalendarMonth(concerts []models.Concert, year int, month time.Month) models.CalendarMonth {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	next := first.AddDate(0, 1, 0)
+
+	cal := models.CalendarMonth{
+		Year:  year,
+		Month: month,
+		Name:  fmt.Sprintf("%s %d", MonthName(month), year),
+	}
+
+	byDay := make(map[time.Time][]models.Concert)
+	for _, concert := range concerts {
+		if concert.Date.Before(first) || !concert.Date.Before(next) {
+			continue
+		}
+		day := concert.Date.UTC().Truncate(24 * time.Hour)
+		byDay[day] = append(byDay[day], concert)
+		cal.Concerts++
+	}
+
+	// Reculer jusqu'au lundi précédant le premier du mois
+	offset := (int(first.Weekday()) + 6) % 7
+	day := first.AddDate(0, 0, -offset)
+
+	for day.Before(next) {
+		week := make([]models.CalendarDay, 7)
+		for i := range week {
+			week[i] = models.CalendarDay{
+				Date:     day,
+				InMonth:  day.Month() == month,
+				Concerts: byDay[day],
+			}
+			day = day.AddDate(0, 0, 1)
+		}
+		cal.Weeks = append(cal.Weeks, week)
+	}
+
+	return cal
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"api-groupie-tracker/models"
+	"testing"
+	"time"
+)
+
+func TestBuildCalendarMonth(t *testing.T) {
+	concerts := []models.Concert{
+		{ArtistID: 1, Location: "lyon-france", Date: time.Date(2019, time.August, 23, 0, 0, 0, 0, time.UTC)},
+		{ArtistID: 2, Location: "paris-france", Date: time.Date(2019, time.August, 23, 0, 0, 0, 0, time.UTC)},
+		{ArtistID: 1, Location: "paris-france", Date: time.Date(2019, time.September, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	cal := BuildCalendarMonth(concerts, 2019, time.August)
+
+	if cal.Name != "août 2019" {
+		t.Errorf("Name = %q; expected %q", cal.Name, "août 2019")
+	}
+	if cal.Concerts != 2 {
+		t.Errorf("Concerts = %d; expected 2", cal.Concerts)
+	}
+
+	// Le 1er août 2019 est un jeudi : la grille commence le lundi 29 juillet
+	first := cal.Weeks[0][0]
+	if first.Date.Day() != 29 || first.InMonth {
+		t.Errorf("First cell = %v (in month: %v); expected Monday 29 July", first.Date, first.InMonth)
+	}
+	if len(cal.Weeks) != 5 {
+		t.Errorf("Weeks = %d; expected 5", len(cal.Weeks))
+	}
+
+	// 23 août : quatrième semaine, vendredi
+	day := cal.Weeks[3][4]
+	if day.Date.Day() != 23 || len(day.Concerts) != 2 {
+		t.Errorf("Cell %v has %d concerts; expected 23 August with 2", day.Date, len(day.Concerts))
+	}
+}
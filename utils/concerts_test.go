@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"api-groupie-tracker/models"
+	"testing"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"23-08-2019", "2019-08-23", true},
+		{"*05-12-2019", "2019-12-05", true},
+		{" 01-01-1990 ", "1990-01-01", true},
+		{"2019-08-23", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		result, err := ParseDate(test.input)
+		if (err == nil) != test.valid {
+			t.Errorf("ParseDate(%s) error = %v; expected valid = %v", test.input, err, test.valid)
+			continue
+		}
+		if test.valid && result.Format("2006-01-02") != test.expected {
+			t.Errorf("ParseDate(%s) = %s; expected %s", test.input, result.Format("2006-01-02"), test.expected)
+		}
+	}
+}
+
+func TestBuildConcerts(t *testing.T) {
+	artists := []models.Artist{
+		{ID: 1, Name: "Queen"},
+		{ID: 2, Name: "Pink Floyd"},
+	}
+	relations := []models.Relation{
+		{ID: 1, DatesLocations: map[string][]string{
+			"lyon-france":  {"20-08-2019", "invalid"},
+			"paris-france": {"18-08-2019"},
+		}},
+		{ID: 2, DatesLocations: map[string][]string{
+			"lyon-france": {"20-08-2019"},
+		}},
+	}
+
+	concerts := BuildConcerts(artists, relations)
+	if len(concerts) != 3 {
+		t.Fatalf("BuildConcerts returned %d concerts; expected 3", len(concerts))
+	}
+
+	if concerts[0].Location != "paris-france" || concerts[0].ArtistName != "Queen" {
+		t.Errorf("First concert = %+v; expected Queen in paris-france", concerts[0])
+	}
+	if concerts[1].ArtistID != 1 || concerts[2].ArtistID != 2 {
+		t.Errorf("Concerts on the same date should be ordered by artist ID")
+	}
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"api-groupie-tracker/models"
+)
+
+// Types d'entrées des flux
+const (
+	FeedKindArtist  = "artist"
+	FeedKindConcert = "concert"
+)
+
+// DiffDatasets compare deux chargements successifs et retourne les artistes
+// et concerts apparus, datés de added
+func DiffDatasets(oldArtists, newArtists []models.Artist, oldConcerts, newConcerts []models.Concert, added time.Time) []models.FeedEntry {
+	var entries []models.FeedEntry
+
+	known := make(map[int]bool, len(oldArtists))
+	for _, artist := range oldArtists {
+		known[artist.ID] = true
+	}
+
+	countries := make(map[int][]string)
+	seenCountry := make(map[string]bool)
+	for _, concert := range newConcerts {
+		country := LocationCountry(concert.Location)
+		key := fmt.Sprintf("%d-%s", concert.ArtistID, country)
+		if !seenCountry[key] {
+			seenCountry[key] = true
+			countries[concert.ArtistID] = append(countries[concert.ArtistID], country)
+		}
+	}
+
+	for _, artist := range newArtists {
+		if known[artist.ID] {
+			continue
+		}
+		entries = append(entries, models.FeedEntry{
+			ID:         fmt.Sprintf("urn:groupie-tracker:artist:%d", artist.ID),
+			Kind:       FeedKindArtist,
+			ArtistID:   artist.ID,
+			ArtistName: artist.Name,
+			Countries:  countries[artist.ID],
+			Added:      added,
+		})
+	}
+
+	knownConcerts := make(map[string]bool, len(oldConcerts))
+	for _, concert := range oldConcerts {
+		knownConcerts[ConcertUID(concert)] = true
+	}
+
+	for _, concert := range newConcerts {
+		uid := ConcertUID(concert)
+		if knownConcerts[uid] {
+			continue
+		}
+		entries = append(entries, models.FeedEntry{
+			ID:         "urn:groupie-tracker:concert:" + uid,
+			Kind:       FeedKindConcert,
+			ArtistID:   concert.ArtistID,
+			ArtistName: concert.ArtistName,
+			Location:   concert.Location,
+			Date:       concert.Date,
+			Countries:  []string{LocationCountry(concert.Location)},
+			Added:      added,
+		})
+	}
+
+	return entries
+}
+
+// FilterFeed retourne les entrées d'un type, éventuellement limitées à un
+// artiste (artistID > 0) ou à un pays, de la plus récente à la plus ancienne
+func FilterFeed(entries []models.FeedEntry, kind string, artistID int, country string) []models.FeedEntry {
+	country = NormalizeLocation(country)
+
+	var filtered []models.FeedEntry
+	for _, entry := range entries {
+		if entry.Kind != kind {
+			continue
+		}
+		if artistID > 0 && entry.ArtistID != artistID {
+			continue
+		}
+		if country != "" && !containsCountry(entry.Countries, country) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Added.After(filtered[j].Added)
+	})
+
+	return filtered
+}
+
+func containsCountry(countries []string, country string) bool {
+	for _, c := range countries {
+		if NormalizeLocation(c) == country {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"api-groupie-tracker/models"
+	"testing"
+	"time"
+)
+
+func TestDiffDatasets(t *testing.T) {
+	oldArtists := []models.Artist{{ID: 1, Name: "Queen"}}
+	oldRelations := []models.Relation{
+		{ID: 1, DatesLocations: map[string][]string{"lyon-france": {"20-08-2019"}}},
+	}
+	newArtists := []models.Artist{{ID: 1, Name: "Queen"}, {ID: 2, Name: "Pink Floyd"}}
+	newRelations := []models.Relation{
+		{ID: 1, DatesLocations: map[string][]string{"lyon-france": {"20-08-2019", "22-08-2019"}}},
+		{ID: 2, DatesLocations: map[string][]string{"london-uk": {"01-09-2019"}}},
+	}
+
+	added := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := DiffDatasets(
+		oldArtists, newArtists,
+		BuildConcerts(oldArtists, oldRelations), BuildConcerts(newArtists, newRelations),
+		added,
+	)
+
+	artists := FilterFeed(entries, FeedKindArtist, 0, "")
+	if len(artists) != 1 || artists[0].ArtistID != 2 || artists[0].Countries[0] != "uk" {
+		t.Errorf("New artists = %+v; expected Pink Floyd touring the uk", artists)
+	}
+
+	concerts := FilterFeed(entries, FeedKindConcert, 0, "")
+	if len(concerts) != 2 {
+		t.Fatalf("New concerts = %d; expected 2", len(concerts))
+	}
+
+	if got := FilterFeed(entries, FeedKindConcert, 1, ""); len(got) != 1 || got[0].Date.Day() != 22 {
+		t.Errorf("Concerts scoped to artist 1 = %+v; expected the 22-08-2019 date", got)
+	}
+	if got := FilterFeed(entries, FeedKindConcert, 0, "France"); len(got) != 1 || got[0].ArtistID != 1 {
+		t.Errorf("Concerts scoped to France = %+v; expected only Queen", got)
+	}
+
+	if got := DiffDatasets(newArtists, newArtists, nil, nil, added); len(got) != 0 {
+		t.Errorf("Identical datasets should produce no entries, got %+v", got)
+	}
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"api-groupie-tracker/geo"
+	"api-groupie-tracker/models"
+)
+
+// Longueur maximale d'une ligne iCalendar en octets, hors CRLF (RFC 5545 §3.1)
+const icsLineLimit = 75
+
+// ConcertUID retourne un identifiant stable pour un concert : il ne dépend
+// que de l'artiste, du lieu et de la date, pour qu'un rafraîchissement des
+// données mette à jour l'événement existant au lieu de le dupliquer
+func ConcertUID(concert models.Concert) string {
+	key := fmt.Sprintf("%d|%s|%s", concert.ArtistID, NormalizeLocation(concert.Location), concert.Date.Format("2006-01-02"))
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:10]) + "@groupie-tracker"
+}
+
+// WriteICS écrit un calendrier RFC 5545 avec un VEVENT (journée entière) par concert
+func WriteICS(w io.Writer, name string, concerts []models.Concert, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		writeFolded(bw, s)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Groupie Tracker//Concerts//FR")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeICSText(name))
+
+	dtstamp := stamp.UTC().Format("20060102T150405Z")
+
+	for _, concert := range concerts {
+		place := FormatLocation(concert.Location)
+
+		line("BEGIN:VEVENT")
+		line("UID:" + ConcertUID(concert))
+		line("DTSTAMP:" + dtstamp)
+		line("DTSTART;VALUE=DATE:" + concert.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + concert.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeICSText(concert.ArtistName+" - "+place))
+		line("LOCATION:" + escapeICSText(place))
+		if p, _, ok := geo.Lookup(concert.Location); ok {
+			line(fmt.Sprintf("GEO:%.4f;%.4f", p.Lat, p.Lon))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// escapeICSText échappe une valeur TEXT (RFC 5545 §3.3.11)
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeFolded écrit une ligne terminée par CRLF en la repliant tous les
+// 75 octets sans couper un caractère UTF-8 (RFC 5545 §3.1)
+func writeFolded(w *bufio.Writer, s string) {
+	limit := icsLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		// Les lignes de continuation commencent par une espace
+		limit = icsLineLimit - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"api-groupie-tracker/models"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConcertUIDIsStable(t *testing.T) {
+	concert := models.Concert{
+		ArtistID:   1,
+		ArtistName: "Queen",
+		Location:   "lyon-france",
+		Date:       time.Date(2019, time.August, 23, 0, 0, 0, 0, time.UTC),
+	}
+
+	renamed := concert
+	renamed.ArtistName = "Queen (remastered)"
+	renamed.Location = "Lyon-France"
+	if ConcertUID(concert) != ConcertUID(renamed) {
+		t.Errorf("UID should only depend on artist ID, normalized location and date")
+	}
+
+	moved := concert
+	moved.Date = moved.Date.AddDate(0, 0, 1)
+	if ConcertUID(concert) == ConcertUID(moved) {
+		t.Errorf("Concerts on different dates should have different UIDs")
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	concerts := []models.Concert{{
+		ArtistID:   1,
+		ArtistName: "Crosby, Stills; Nash & Young and a very long name that needs folding",
+		Location:   "lyon-france",
+		Date:       time.Date(2019, time.August, 23, 0, 0, 0, 0, time.UTC),
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, "Tournée", concerts, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:" + ConcertUID(concerts[0]) + "\r\n",
+		"DTSTAMP:20200102T030405Z\r\n",
+		"DTSTART;VALUE=DATE:20190823\r\n",
+		"DTEND;VALUE=DATE:20190824\r\n",
+		`Crosby\, Stills\; Nash`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("ICS output missing %q:\n%s", want, out)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line exceeds 75 octets: %q", line)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"api-groupie-tracker/geo"
+	"api-groupie-tracker/models"
+)
+
+// Nombre d'entrées des classements du tableau de bord
+const statsTopN = 10
+
+// ComputeStats agrège les artistes et concerts pour le tableau de bord
+func ComputeStats(artists []models.Artist, concerts []models.Concert) models.Stats {
+	stats := models.Stats{
+		ArtistCount:  len(artists),
+		ConcertCount: len(concerts),
+	}
+
+	// Artistes et taille moyenne des groupes par décennie de création
+	perDecade := make(map[int]int)
+	membersPerDecade := make(map[int]int)
+	for _, artist := range artists {
+		decade := artist.CreationDate / 10 * 10
+		perDecade[decade]++
+		membersPerDecade[decade] += len(artist.Members)
+	}
+
+	decades := sortedKeys(perDecade)
+	for _, decade := range decades {
+		stats.ArtistsPerDecade = append(stats.ArtistsPerDecade, models.StatBar{
+			Key:   "stats.decade",
+			Args:  []interface{}{decade},
+			Value: float64(perDecade[decade]),
+		})
+		avg := float64(membersPerDecade[decade]) / float64(perDecade[decade])
+		stats.BandSizeByDecade = append(stats.BandSizeByDecade, models.StatBar{
+			Key:   "stats.decade",
+			Args:  []interface{}{decade},
+			Value: math.Round(avg*10) / 10,
+		})
+	}
+
+	// Pays, villes et années les plus actifs
+	perCountry := make(map[string]int)
+	perCity := make(map[string]int)
+	perYear := make(map[int]int)
+	for _, concert := range concerts {
+		perCountry[LocationCountry(concert.Location)]++
+		perCity[concert.Location]++
+		perYear[concert.Date.Year()]++
+	}
+	stats.CountryCount = len(perCountry)
+	stats.LocationCount = len(perCity)
+	stats.BusiestCountries = topBars(perCountry, statsTopN)
+	stats.BusiestCities = topBars(perCity, statsTopN)
+
+	for _, year := range sortedKeys(perYear) {
+		stats.ConcertsPerYear = append(stats.ConcertsPerYear, models.StatBar{
+			Label: strconv.Itoa(year),
+			Value: float64(perYear[year]),
+		})
+	}
+
+	stats.MostTravelled = mostTravelled(artists, concerts, statsTopN)
+
+	// Écart entre la création et le premier album
+	gapBuckets := []string{"stats.gap.sameYear", "stats.gap.oneYear", "stats.gap.twoThree", "stats.gap.fourFive", "stats.gap.sixPlus"}
+	gapCounts := make([]int, len(gapBuckets))
+	totalGap, counted := 0, 0
+	for _, artist := range artists {
+		album := ExtractYear(artist.FirstAlbum)
+		if album == 0 || artist.CreationDate == 0 {
+			continue
+		}
+		gap := album - artist.CreationDate
+		if gap < 0 {
+			gap = 0
+		}
+		totalGap += gap
+		counted++
+
+		switch {
+		case gap == 0:
+			gapCounts[0]++
+		case gap == 1:
+			gapCounts[1]++
+		case gap <= 3:
+			gapCounts[2]++
+		case gap <= 5:
+			gapCounts[3]++
+		default:
+			gapCounts[4]++
+		}
+	}
+	for i, key := range gapBuckets {
+		stats.CreationToAlbum = append(stats.CreationToAlbum, models.StatBar{
+			Key:   key,
+			Value: float64(gapCounts[i]),
+		})
+	}
+	if counted > 0 {
+		stats.AverageCreationToAlbum = math.Round(float64(totalGap)/float64(counted)*10) / 10
+	}
+
+	for _, bars := range [][]models.StatBar{
+		stats.ArtistsPerDecade, stats.BandSizeByDecade, stats.BusiestCountries,
+		stats.BusiestCities, stats.ConcertsPerYear, stats.MostTravelled, stats.CreationToAlbum,
+	} {
+		scaleBars(bars)
+	}
+
+	return stats
+}
+
+// mostTravelled classe les artistes par distance parcourue en suivant
+// leurs concerts dans l'ordre chronologique
+func mostTravelled(artists []models.Artist, concerts []models.Concert, n int) []models.StatBar {
+	names := make(map[int]string, len(artists))
+	for _, artist := range artists {
+		names[artist.ID] = artist.Name
+	}
+
+	distance := make(map[int]float64)
+	last := make(map[int]geo.Point)
+	for _, concert := range concerts {
+		p, _, ok := geo.Lookup(concert.Location)
+		if !ok {
+			continue
+		}
+		if prev, seen := last[concert.ArtistID]; seen {
+			distance[concert.ArtistID] += geo.Distance(prev, p)
+		}
+		last[concert.ArtistID] = p
+	}
+
+	var bars []models.StatBar
+	for id, km := range distance {
+		bars = append(bars, models.StatBar{Label: names[id], Value: math.Round(km)})
+	}
+	sortBars(bars)
+
+	if len(bars) > n {
+		bars = bars[:n]
+	}
+	return bars
+}
+
+// topBars retourne les n clés les plus fréquentes
+func topBars(counts map[string]int, n int) []models.StatBar {
+	bars := make([]models.StatBar, 0, len(counts))
+	for label, count := range counts {
+		bars = append(bars, models.StatBar{Label: label, Value: float64(count)})
+	}
+	sortBars(bars)
+
+	if len(bars) > n {
+		bars = bars[:n]
+	}
+	return bars
+}
+
+// sortBars trie par valeur décroissante puis par libellé
+func sortBars(bars []models.StatBar) {
+	sort.Slice(bars, func(i, j int) bool {
+		if bars[i].Value != bars[j].Value {
+			return bars[i].Value > bars[j].Value
+		}
+		return bars[i].Label < bars[j].Label
+	})
+}
+
+// scaleBars calcule la largeur relative de chaque barre par rapport au maximum
+func scaleBars(bars []models.StatBar) {
+	max := 0.0
+	for _, bar := range bars {
+		max = math.Max(max, bar.Value)
+	}
+	if max == 0 {
+		return
+	}
+	for i := range bars {
+		bars[i].Percent = int(math.Round(bars[i].Value / max * 100))
+	}
+}
+
+func sortedKeys(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
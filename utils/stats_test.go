@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"api-groupie-tracker/models"
+	"testing"
+)
+
+func TestComputeStats(t *testing.T) {
+	artists := []models.Artist{
+		{ID: 1, Name: "Queen", Members: []string{"A", "B", "C", "D"}, CreationDate: 1970, FirstAlbum: "14-12-1973"},
+		{ID: 2, Name: "Pink Floyd", Members: []string{"E", "F"}, CreationDate: 1965, FirstAlbum: "05-08-1965"},
+	}
+	relations := []models.Relation{
+		{ID: 1, DatesLocations: map[string][]string{
+			"paris-france": {"01-07-2019"},
+			"lyon-france":  {"03-07-2019"},
+		}},
+		{ID: 2, DatesLocations: map[string][]string{
+			"london-uk": {"01-07-2020"},
+		}},
+	}
+
+	stats := ComputeStats(artists, BuildConcerts(artists, relations))
+
+	if stats.ArtistCount != 2 || stats.ConcertCount != 3 || stats.CountryCount != 2 || stats.LocationCount != 3 {
+		t.Errorf("Counts = %+v; expected 2 artists, 3 concerts, 3 locations, 2 countries", stats)
+	}
+
+	if len(stats.ArtistsPerDecade) != 2 || stats.ArtistsPerDecade[0].Key != "stats.decade" ||
+		stats.ArtistsPerDecade[0].Args[0] != 1960 {
+		t.Errorf("ArtistsPerDecade = %+v; expected 1960s then 1970s", stats.ArtistsPerDecade)
+	}
+
+	if stats.BusiestCountries[0].Label != "france" || stats.BusiestCountries[0].Percent != 100 {
+		t.Errorf("BusiestCountries[0] = %+v; expected france at 100%%", stats.BusiestCountries[0])
+	}
+
+	if len(stats.MostTravelled) != 1 || stats.MostTravelled[0].Label != "Queen" {
+		t.Errorf("MostTravelled = %+v; expected only Queen (Paris → Lyon)", stats.MostTravelled)
+	}
+
+	if len(stats.CreationToAlbum) != 5 || stats.CreationToAlbum[0].Key != "stats.gap.sameYear" || stats.CreationToAlbum[0].Value != 1 {
+		t.Errorf("CreationToAlbum = %+v; expected Pink Floyd in the same-year bucket", stats.CreationToAlbum)
+	}
+
+	if stats.AverageCreationToAlbum != 1.5 {
+		t.Errorf("AverageCreationToAlbum = %v; expected 1.5", stats.AverageCreationToAlbum)
+	}
+}
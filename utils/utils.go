@@ -2,10 +2,18 @@ package utils
 
 import (
 	"api-groupie-tracker/models"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
+// DateLayout est le format des dates renvoyées par l'API
+const DateLayout = "02-01-2006"
+
 // ExtractYear extrait l'année d'une date au format "DD-MM-YYYY"
 func ExtractYear(dateStr string) int {
 	parts := strings.Split(dateStr, "-")
@@ -16,6 +24,22 @@ func ExtractYear(dateStr string) int {
 	return 0
 }
 
+// ParseDate convertit une date de l'API ("DD-MM-YYYY", parfois préfixée par "*")
+func ParseDate(dateStr string) (time.Time, error) {
+	dateStr = strings.TrimPrefix(strings.TrimSpace(dateStr), "*")
+	return time.Parse(DateLayout, dateStr)
+}
+
+// ParseLocalDate lit une date "DD-MM-YYYY" de l'API ; une date illisible
+// donne la date zéro
+func ParseLocalDate(dateStr string) models.LocalDate {
+	t, err := ParseDate(dateStr)
+	if err != nil {
+		return models.LocalDate{}
+	}
+	return models.LocalDate{Time: t}
+}
+
 // FilterArtists filtre les artistes selon les critères
 func FilterArtists(artists []models.Artist, criteria models.FilterCriteria) []models.FullArtist {
 	var filtered []models.FullArtist
@@ -59,6 +83,37 @@ func FilterArtists(artists []models.Artist, criteria models.FilterCriteria) []mo
 	return filtered
 }
 
+// FilterConcerts retourne les concerts correspondant aux critères
+func FilterConcerts(concerts []models.Concert, criteria models.ConcertCriteria) []models.Concert {
+	artistSet := make(map[int]bool, len(criteria.ArtistIDs))
+	for _, id := range criteria.ArtistIDs {
+		artistSet[id] = true
+	}
+	country := NormalizeLocation(criteria.Country)
+
+	var filtered []models.Concert
+	for _, concert := range concerts {
+		if len(artistSet) > 0 && !artistSet[concert.ArtistID] {
+			continue
+		}
+		if criteria.Location != "" && !LocationContains(concert.Location, criteria.Location) {
+			continue
+		}
+		if country != "" && NormalizeLocation(LocationCountry(concert.Location)) != country {
+			continue
+		}
+		if !criteria.From.IsZero() && concert.Date.Before(criteria.From) {
+			continue
+		}
+		if !criteria.To.IsZero() && concert.Date.After(criteria.To) {
+			continue
+		}
+		filtered = append(filtered, concert)
+	}
+
+	return filtered
+}
+
 // NormalizeLocation normalise une location pour la comparaison
 func NormalizeLocation(location string) string {
 	location = strings.ToLower(location)
@@ -163,6 +218,43 @@ func SearchInLocations(locations []string, query string) bool {
 	return false
 }
 
+// LocationCountry extrait le pays d'une location "ville-pays"
+func LocationCountry(location string) string {
+	location = strings.ToLower(strings.TrimSpace(location))
+	if i := strings.LastIndex(location, "-"); i >= 0 {
+		return location[i+1:]
+	}
+	return location
+}
+
+// FormatLocation rend une location lisible : "new_york-usa" → "New York, USA"
+func FormatLocation(location string) string {
+	location = strings.ToLower(strings.TrimSpace(location))
+	parts := []string{location}
+	if i := strings.LastIndex(location, "-"); i >= 0 {
+		parts = []string{location[:i], location[i+1:]}
+	}
+
+	for i, part := range parts {
+		words := strings.FieldsFunc(part, func(r rune) bool {
+			return r == '_' || r == '-' || r == ' '
+		})
+		for j, word := range words {
+			if len(word) <= 3 && len(words) == 1 && i == len(parts)-1 && len(parts) > 1 {
+				// Sigles de pays : usa, uk
+				words[j] = strings.ToUpper(word)
+			} else {
+				// Première lettre éventuellement accentuée : "île" → "Île"
+				first, size := utf8.DecodeRuneInString(word)
+				words[j] = string(unicode.ToUpper(first)) + word[size:]
+			}
+		}
+		parts[i] = strings.Join(words, " ")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
 // GetUniqueLocations retourne toutes les locations uniques
 func GetUniqueLocations(artists []models.Artist, relations []models.Relation) []string {
 	locationSet := make(map[string]bool)
@@ -234,4 +326,239 @@ func GetMembersRange(artists []models.Artist) (min, max int) {
 	}
 
 	return
-}
\ No newline at end of file
+}
+
+// BuildConcerts aplatit les relations en une liste de concerts triée par date.
+// Les dates illisibles sont ignorées.
+func BuildConcerts(artists []models.Artist, relations []models.Relation) []models.Concert {
+	names := make(map[int]string, len(artists))
+	for _, artist := range artists {
+		names[artist.ID] = artist.Name
+	}
+
+	var concerts []models.Concert
+	for _, relation := range relations {
+		for location, dates := range relation.DatesLocations {
+			for _, d := range dates {
+				date, err := ParseDate(d)
+				if err != nil {
+					continue
+				}
+				concerts = append(concerts, models.Concert{
+					ArtistID:   relation.ID,
+					ArtistName: names[relation.ID],
+					Location:   location,
+					Date:       date,
+				})
+			}
+		}
+	}
+
+	sort.Slice(concerts, func(i, j int) bool {
+		if !concerts[i].Date.Equal(concerts[j].Date) {
+			return concerts[i].Date.Before(concerts[j].Date)
+		}
+		if concerts[i].ArtistID != concerts[j].ArtistID {
+			return concerts[i].ArtistID < concerts[j].ArtistID
+		}
+		return concerts[i].Location < concerts[j].Location
+	})
+
+	return concerts
+}
+
+// FindOverlaps détecte, pour chaque artiste, les concerts d'autres artistes
+// dans la même location à maxDays jours d'écart au plus.
+// Les concerts doivent être triés par date (voir BuildConcerts).
+func FindOverlaps(concerts []models.Concert, maxDays int) map[int][]models.Overlap {
+	overlaps := make(map[int][]models.Overlap)
+
+	// Regrouper par location normalisée en conservant l'ordre chronologique
+	byLocation := make(map[string][]models.Concert)
+	for _, concert := range concerts {
+		key := NormalizeLocation(concert.Location)
+		byLocation[key] = append(byLocation[key], concert)
+	}
+
+	window := time.Duration(maxDays) * 24 * time.Hour
+
+	for _, group := range byLocation {
+		for i, a := range group {
+			for j := i + 1; j < len(group); j++ {
+				b := group[j]
+				if b.Date.Sub(a.Date) > window {
+					break
+				}
+				if a.ArtistID == b.ArtistID {
+					continue
+				}
+
+				days := int(b.Date.Sub(a.Date).Hours() / 24)
+				overlaps[a.ArtistID] = append(overlaps[a.ArtistID], models.Overlap{
+					Location:        a.Location,
+					Date:            a.Date,
+					OtherArtistID:   b.ArtistID,
+					OtherArtistName: b.ArtistName,
+					OtherDate:       b.Date,
+					DaysApart:       days,
+				})
+				overlaps[b.ArtistID] = append(overlaps[b.ArtistID], models.Overlap{
+					Location:        b.Location,
+					Date:            b.Date,
+					OtherArtistID:   a.ArtistID,
+					OtherArtistName: a.ArtistName,
+					OtherDate:       a.Date,
+					DaysApart:       -days,
+				})
+			}
+		}
+	}
+
+	for id := range overlaps {
+		list := overlaps[id]
+		sort.Slice(list, func(i, j int) bool {
+			if !list[i].Date.Equal(list[j].Date) {
+				return list[i].Date.Before(list[j].Date)
+			}
+			return list[i].OtherArtistID < list[j].OtherArtistID
+		})
+	}
+
+	return overlaps
+}
+
+// Pondération des critères de similarité (total = 1)
+const (
+	weightCreation = 0.2
+	weightAlbum    = 0.2
+	weightMembers  = 0.15
+	weightCountry  = 0.25
+	weightCoBilled = 0.2
+
+	// Écart d'années au-delà duquel deux époques n'ont plus rien en commun
+	eraSpanYears = 20
+	// Nombre de co-plateaux donnant le score maximal sur ce critère
+	coBilledCap = 3
+	// Score en dessous duquel la recommandation n'est pas pertinente
+	minSimilarity = 0.1
+)
+
+// RankSimilar calcule pour chaque artiste les limit artistes les plus proches
+// selon l'époque (création, premier album), le nombre de membres, les pays de
+// tournée communs et les concerts partagés. Les raisons sont des clés du
+// catalogue i18n, traduites à l'affichage.
+func RankSimilar(artists []models.Artist, concerts []models.Concert, overlaps map[int][]models.Overlap, limit int) map[int][]models.SimilarArtist {
+	countries := make(map[int]map[string]bool)
+	for _, concert := range concerts {
+		if countries[concert.ArtistID] == nil {
+			countries[concert.ArtistID] = make(map[string]bool)
+		}
+		countries[concert.ArtistID][LocationCountry(concert.Location)] = true
+	}
+
+	coBilled := make(map[int]map[int]int)
+	for id, list := range overlaps {
+		coBilled[id] = make(map[int]int)
+		for _, overlap := range list {
+			coBilled[id][overlap.OtherArtistID]++
+		}
+	}
+
+	ranking := make(map[int][]models.SimilarArtist, len(artists))
+
+	for _, a := range artists {
+		var candidates []models.SimilarArtist
+
+		for _, b := range artists {
+			if a.ID == b.ID {
+				continue
+			}
+
+			var score float64
+			var reasons []models.SimilarReason
+
+			if s := closeness(a.CreationDate, b.CreationDate, eraSpanYears); s > 0 {
+				score += weightCreation * s
+				if a.CreationDate == b.CreationDate {
+					reasons = append(reasons, models.SimilarReason{Key: "similar.created", Value: b.CreationDate})
+				}
+			}
+
+			albumA, albumB := ExtractYear(a.FirstAlbum), ExtractYear(b.FirstAlbum)
+			if albumA > 0 && albumB > 0 {
+				if s := closeness(albumA, albumB, eraSpanYears); s > 0 {
+					score += weightAlbum * s
+					if s >= 0.75 {
+						reasons = append(reasons, models.SimilarReason{Key: "similar.sameEra"})
+					}
+				}
+			}
+
+			membersA, membersB := len(a.Members), len(b.Members)
+			if membersA > 0 && membersB > 0 {
+				maxMembers := membersA
+				if membersB > maxMembers {
+					maxMembers = membersB
+				}
+				score += weightMembers * closeness(membersA, membersB, maxMembers)
+				if membersA == membersB {
+					reasons = append(reasons, models.SimilarReason{Key: "similar.members", Value: membersB})
+				}
+			}
+
+			shared, union := 0, len(countries[a.ID])
+			for country := range countries[b.ID] {
+				if countries[a.ID][country] {
+					shared++
+				} else {
+					union++
+				}
+			}
+			if shared > 0 {
+				score += weightCountry * float64(shared) / float64(union)
+				reasons = append(reasons, models.SimilarReason{Key: "similar.countries", Value: shared})
+			}
+
+			if n := coBilled[a.ID][b.ID]; n > 0 {
+				score += weightCoBilled * math.Min(float64(n), coBilledCap) / coBilledCap
+				reasons = append(reasons, models.SimilarReason{Key: "similar.concerts", Value: n})
+			}
+
+			if score < minSimilarity {
+				continue
+			}
+
+			candidates = append(candidates, models.SimilarArtist{
+				ID:      b.ID,
+				Name:    b.Name,
+				Image:   b.Image,
+				Score:   math.Round(score*1000) / 1000,
+				Reasons: reasons,
+			})
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].Score != candidates[j].Score {
+				return candidates[i].Score > candidates[j].Score
+			}
+			return candidates[i].ID < candidates[j].ID
+		})
+
+		if len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+		ranking[a.ID] = candidates
+	}
+
+	return ranking
+}
+
+// closeness retourne 1 pour deux valeurs égales et décroît linéairement
+// jusqu'à 0 pour un écart de span
+func closeness(a, b, span int) float64 {
+	if span <= 0 {
+		return 0
+	}
+	diff := math.Abs(float64(a - b))
+	return math.Max(0, 1-diff/float64(span))
+}
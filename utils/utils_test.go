@@ -1,7 +1,7 @@
 package utils
 
 import (
-	"groupie-tracker/models"
+	"api-groupie-tracker/models"
 	"testing"
 )
 
@@ -200,4 +200,93 @@ func TestGetMembersRange(t *testing.T) {
 	if max != 4 {
 		t.Errorf("Max members = %d; expected 4", max)
 	}
-}
\ No newline at end of file
+}
+
+func TestFindOverlaps(t *testing.T) {
+	artists := []models.Artist{
+		{ID: 1, Name: "Queen"},
+		{ID: 2, Name: "Pink Floyd"},
+		{ID: 3, Name: "Scorpions"},
+	}
+	relations := []models.Relation{
+		{ID: 1, DatesLocations: map[string][]string{"werchter-belgium": {"05-07-2019"}}},
+		{ID: 2, DatesLocations: map[string][]string{"werchter-belgium": {"06-07-2019"}}},
+		{ID: 3, DatesLocations: map[string][]string{
+			"werchter-belgium": {"10-07-2019"},
+			"lyon-france":      {"05-07-2019"},
+		}},
+	}
+
+	overlaps := FindOverlaps(BuildConcerts(artists, relations), 1)
+
+	if len(overlaps[1]) != 1 || overlaps[1][0].OtherArtistID != 2 || overlaps[1][0].DaysApart != 1 {
+		t.Errorf("Overlaps for artist 1 = %+v; expected Pink Floyd the next day", overlaps[1])
+	}
+	if len(overlaps[2]) != 1 || overlaps[2][0].OtherArtistID != 1 || overlaps[2][0].DaysApart != -1 {
+		t.Errorf("Overlaps for artist 2 = %+v; expected Queen the day before", overlaps[2])
+	}
+	if len(overlaps[3]) != 0 {
+		t.Errorf("Artist 3 should have no overlap, got %+v", overlaps[3])
+	}
+}
+
+func TestRankSimilar(t *testing.T) {
+	artists := []models.Artist{
+		{ID: 1, Name: "Queen", Members: []string{"A", "B", "C", "D"}, CreationDate: 1970, FirstAlbum: "14-12-1973"},
+		{ID: 2, Name: "Pink Floyd", Members: []string{"E", "F", "G", "H"}, CreationDate: 1965, FirstAlbum: "05-08-1967"},
+		{ID: 3, Name: "Post Malone", Members: []string{"I"}, CreationDate: 2013, FirstAlbum: "09-12-2016"},
+	}
+	relations := []models.Relation{
+		{ID: 1, DatesLocations: map[string][]string{"werchter-belgium": {"05-07-2019"}}},
+		{ID: 2, DatesLocations: map[string][]string{"werchter-belgium": {"05-07-2019"}}},
+		{ID: 3, DatesLocations: map[string][]string{"lyon-france": {"05-07-2019"}}},
+	}
+	concerts := BuildConcerts(artists, relations)
+
+	ranking := RankSimilar(artists, concerts, FindOverlaps(concerts, 1), 5)
+
+	if len(ranking[1]) == 0 || ranking[1][0].ID != 2 {
+		t.Fatalf("Most similar to Queen = %+v; expected Pink Floyd first", ranking[1])
+	}
+	reasons := make(map[string]int)
+	for _, reason := range ranking[1][0].Reasons {
+		reasons[reason.Key] = reason.Value
+	}
+	if reasons["similar.members"] != 4 || reasons["similar.countries"] != 1 || reasons["similar.concerts"] != 1 {
+		t.Errorf("Queen/Pink Floyd reasons = %+v", ranking[1][0].Reasons)
+	}
+	for _, s := range ranking[1] {
+		if s.ID == 3 {
+			t.Errorf("Post Malone shares nothing with Queen and should not be recommended")
+		}
+	}
+
+	ranking = RankSimilar(artists, concerts, nil, 1)
+	if len(ranking[1]) != 1 {
+		t.Errorf("RankSimilar should respect the limit, got %d results", len(ranking[1]))
+	}
+}
+
+func TestFormatLocation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"new_york-usa", "New York, USA"},
+		{"north_carolina-usa", "North Carolina, USA"},
+		{"lyon-france", "Lyon, France"},
+		{"playa_del_carmen-mexico", "Playa Del Carmen, Mexico"},
+		{"london-uk", "London, UK"},
+		{"dunedin-new_zealand", "Dunedin, New Zealand"},
+		{"paris", "Paris"},
+		{"île_de_ré-france", "Île De Ré, France"},
+		{"örebro-sweden", "Örebro, Sweden"},
+	}
+
+	for _, test := range tests {
+		result := FormatLocation(test.input)
+		if result != test.expected {
+			t.Errorf("FormatLocation(%s) = %s; expected %s", test.input, result, test.expected)
+		}
+	}
+}